@@ -3,10 +3,16 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const BashToolName = "bash"
@@ -14,20 +20,67 @@ const BashToolName = "bash"
 // Bash represents a wrapper around the system's bash command-line tool
 type Bash struct {
 	logger      goai.Logger
-	cmdExecutor CommandExecutor
+	cmdExecutor SeparateOutputExecutor
+	config      BashConfig
 }
 
+// bashOutput is the JSON envelope returned by the bash tool
+type bashOutput struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// BashConfig holds the configuration for the Bash tool
+type BashConfig struct {
+	// Timeout bounds the duration of a single command. Zero means no timeout.
+	Timeout time.Duration
+	// WorkingDir is the directory commands run in. Empty means the server's working directory.
+	WorkingDir string
+	// Env is the environment commands run with. Entries are either KEY=VALUE, or a
+	// bare KEY copied from the server's environment when set. Nil inherits the
+	// server's full environment; see DefaultBashEnv for a minimal allowlist.
+	Env []string
+	// MaxOutputBytes caps the size of stdout and of stderr returned to the
+	// model; longer output is cut and marked as truncated. Zero means unlimited.
+	MaxOutputBytes int
+	// CommandExecutor runs bash, e.g. a TimeoutCommandExecutor, which also kills
+	// the background processes of a timed out command. Nil uses RealCommandExecutor.
+	CommandExecutor SeparateOutputExecutor
+}
+
+// bashTruncatedMarker is appended to output cut by BashConfig.MaxOutputBytes
+const bashTruncatedMarker = "…(truncated)"
+
+// DefaultBashEnv is a minimal environment allowlist for BashConfig.Env
+var DefaultBashEnv = []string{"PATH", "HOME", "LANG", "TERM"}
+
+// bashWaitDelay bounds how long output pipes held open by background children
+// may delay returning once the bash process has been killed
+const bashWaitDelay = time.Second
+
 // NewBash creates a new instance of the Bash wrapper
 func NewBash(logger goai.Logger) *Bash {
+	return NewBashWithConfig(logger, BashConfig{})
+}
+
+// NewBashWithConfig creates a new instance of the Bash wrapper with the given configuration
+func NewBashWithConfig(logger goai.Logger, config BashConfig) *Bash {
+	var executor SeparateOutputExecutor = &RealCommandExecutor{}
+	if config.CommandExecutor != nil {
+		executor = config.CommandExecutor
+	}
+
 	return &Bash{
 		logger:      logger,
-		cmdExecutor: &RealCommandExecutor{},
+		cmdExecutor: executor,
+		config:      config,
 	}
 }
 
 // BashAllInOneTool returns a goai.Tool that can execute bash commands
 func (b *Bash) BashAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(b.logger, goai.Tool{
 		Name:        BashToolName,
 		Description: "Execute bash commands with specified script or command",
 		InputSchema: json.RawMessage(`{
@@ -48,32 +101,116 @@ func (b *Bash) BashAllInOneTool() goai.Tool {
             "required": ["command"]
         }`),
 		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
 			var input struct {
 				Command string   `json:"command"`
 				Args    []string `json:"args"`
 			}
 
-			b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Info("Received input", "input", string(params.Arguments))
+			b.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
-				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to parse input", "error", err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to parse input: %w", err)
+				b.logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+				}).Error("Failed to parse input")
+				span.RecordError(err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to parse input: %w", err))
+			}
+
+			b.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"command":   input.Command,
+				"args":      input.Args,
+			}).Info("Executing bash command")
+			if b.config.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, b.config.Timeout)
+				defer cancel()
 			}
 
-			b.logger.Info("Executing bash command", "command", input.Command, "args", input.Args)
-			cmd := exec.Command("bash", append([]string{"-c", input.Command}, input.Args...)...)
-			output, err := b.cmdExecutor.ExecuteCommand(ctx, cmd)
+			cmd := exec.CommandContext(ctx, "bash", append([]string{"-c", input.Command}, input.Args...)...)
+			cmd.WaitDelay = bashWaitDelay
+			cmd.Dir = b.config.WorkingDir
+			if b.config.Env != nil {
+				cmd.Env = buildBashEnv(b.config.Env)
+			}
+			result, err := b.cmdExecutor.ExecuteCommandSeparateOutput(ctx, cmd)
+			if b.config.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = upstreamErrorf("bash command timed out after %s", b.config.Timeout)
+			}
 			if err != nil {
-				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to execute bash command", "error", err)
-				return returnErrorOutput(err), nil
+				b.logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+				}).Error("Failed to execute bash command")
+				span.RecordError(err)
+				return returnErrorOutput(newToolError(ErrorKindUpstream, err)), nil
+			}
+
+			o, err := json.Marshal(bashOutput{
+				Stdout:   truncateBashOutput(result.Stdout, b.config.MaxOutputBytes),
+				Stderr:   truncateBashOutput(result.Stderr, b.config.MaxOutputBytes),
+				ExitCode: result.ExitCode,
+			})
+			if err != nil {
+				span.RecordError(err)
+				return returnErrorOutput(newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal output: %w", err))), nil
+			}
+
+			span.SetAttributes(attribute.Int("exit_code", result.ExitCode))
+			if result.ExitCode != 0 {
+				span.RecordError(fmt.Errorf("bash command exited with code %d", result.ExitCode))
 			}
 
-			o := string(output)
-			b.logger.WithFields(map[string]interface{}{"tool": BashToolName, "output_length": len(o)}).Info("Bash command executed successfully")
+			b.logger.WithFields(map[string]interface{}{
+				"tool_name":     params.Name,
+				"output_length": len(result.Stdout) + len(result.Stderr),
+				"exit_code":     result.ExitCode,
+			}).Info("Bash command executed")
 			return goai.CallToolResult{
-				Content: []goai.ToolResultContent{{Type: "text", Text: o}},
-				IsError: false,
+				Content: []goai.ToolResultContent{{Type: "text", Text: string(o)}},
+				IsError: result.ExitCode != 0,
 			}, nil
 		},
+	})
+}
+
+// truncateBashOutput cuts output down to max bytes, backing off to a UTF-8
+// character boundary, and marks it as truncated. A max of zero or less disables truncation.
+func truncateBashOutput(output []byte, max int) string {
+	if max <= 0 || len(output) <= max {
+		return string(output)
+	}
+
+	cut := max
+	for cut > 0 && !utf8.RuneStart(output[cut]) {
+		cut--
+	}
+	return string(output[:cut]) + bashTruncatedMarker
+}
+
+// buildBashEnv resolves the configured environment entries, copying bare
+// keys from the server's environment and dropping the ones that are unset
+func buildBashEnv(entries []string) []string {
+	env := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if strings.Contains(entry, "=") {
+			env = append(env, entry)
+			continue
+		}
+		if value, ok := os.LookupEnv(entry); ok {
+			env = append(env, entry+"="+value)
+		}
 	}
+	return env
 }
@@ -0,0 +1,135 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runBashTool(t *testing.T, bash *Bash, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+
+	inputJSON, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := bash.BashAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      BashToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func parseBashOutput(t *testing.T, result goai.CallToolResult) bashOutput {
+	t.Helper()
+
+	var output bashOutput
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &output))
+	return output
+}
+
+func newBashTestLogger() *MockLogger {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+	return mockLogger
+}
+
+func TestBash_Timeout(t *testing.T) {
+	bash := NewBashWithConfig(newBashTestLogger(), BashConfig{Timeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	result := runBashTool(t, bash, map[string]interface{}{"command": "sleep 5"})
+
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "bash command timed out after 100ms")
+}
+
+func TestBash_WorkingDir(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	bash := NewBashWithConfig(newBashTestLogger(), BashConfig{WorkingDir: dir})
+
+	result := runBashTool(t, bash, map[string]interface{}{"command": "pwd -P"})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, dir, strings.TrimSpace(parseBashOutput(t, result).Stdout))
+}
+
+func TestBash_Env(t *testing.T) {
+	t.Setenv("BASH_TEST_SECRET", "secret-value")
+	t.Setenv("BASH_TEST_ALLOWED", "allowed-value")
+
+	bash := NewBashWithConfig(newBashTestLogger(), BashConfig{
+		Env: []string{"PATH", "BASH_TEST_ALLOWED", "BASH_TEST_UNSET_VAR", "FOO=bar"},
+	})
+
+	result := runBashTool(t, bash, map[string]interface{}{
+		"command": `echo "$FOO|$BASH_TEST_ALLOWED|${BASH_TEST_SECRET-absent}|${BASH_TEST_UNSET_VAR-absent}"`,
+	})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, "bar|allowed-value|absent|absent", strings.TrimSpace(parseBashOutput(t, result).Stdout))
+}
+
+func TestBash_SeparateOutput(t *testing.T) {
+	bash := NewBash(newBashTestLogger())
+
+	result := runBashTool(t, bash, map[string]interface{}{
+		"command": "echo to-stdout; echo to-stderr >&2; exit 3",
+	})
+
+	assert.True(t, result.IsError)
+	output := parseBashOutput(t, result)
+	assert.Equal(t, "to-stdout\n", output.Stdout)
+	assert.Equal(t, "to-stderr\n", output.Stderr)
+	assert.Equal(t, 3, output.ExitCode)
+}
+
+func TestBash_Tracing(t *testing.T) {
+	bash := NewBash(newBashTestLogger())
+
+	ctx, recorder := newTracingTestContext(t)
+	inputJSON, err := json.Marshal(map[string]interface{}{"command": "echo ok"})
+	require.NoError(t, err)
+	_, err = bash.BashAllInOneTool().Handler(ctx, goai.CallToolParams{Name: BashToolName, Arguments: inputJSON})
+	require.NoError(t, err)
+	assert.False(t, spanRecordedError(requireHandlerSpan(t, recorder, BashToolName)))
+
+	ctx, recorder = newTracingTestContext(t)
+	inputJSON, err = json.Marshal(map[string]interface{}{"command": "exit 3"})
+	require.NoError(t, err)
+	_, err = bash.BashAllInOneTool().Handler(ctx, goai.CallToolParams{Name: BashToolName, Arguments: inputJSON})
+	require.NoError(t, err)
+	assert.True(t, spanRecordedError(requireHandlerSpan(t, recorder, BashToolName)))
+}
+
+func TestBash_MaxOutputBytes(t *testing.T) {
+	bash := NewBashWithConfig(newBashTestLogger(), BashConfig{MaxOutputBytes: 10})
+
+	output := parseBashOutput(t, runBashTool(t, bash, map[string]interface{}{
+		"command": "printf '%0100d' 0; printf 'short' >&2",
+	}))
+
+	assert.Equal(t, strings.Repeat("0", 10)+bashTruncatedMarker, output.Stdout)
+	assert.Equal(t, "short", output.Stderr)
+}
+
+func TestTruncateBashOutput(t *testing.T) {
+	assert.Equal(t, "hello", truncateBashOutput([]byte("hello"), 0))
+	assert.Equal(t, "hello", truncateBashOutput([]byte("hello"), 5))
+	assert.Equal(t, "he"+bashTruncatedMarker, truncateBashOutput([]byte("hello"), 2))
+	// A multi-byte character is never split
+	assert.Equal(t, "a"+bashTruncatedMarker, truncateBashOutput([]byte("aé"), 2))
+}
@@ -1,13 +1,17 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const CatToolName = "cat"
@@ -16,19 +20,37 @@ const CatToolName = "cat"
 type Cat struct {
 	logger      goai.Logger
 	cmdExecutor CommandExecutor
+	config      CatConfig
+}
+
+// CatConfig holds the configuration for the Cat tool
+type CatConfig struct {
+	UseNativeReader  bool   // Read files in Go instead of executing the cat binary; only -n is supported
+	AllowedDirectory string // Base directory files must reside in; empty means unrestricted
+	MaxBytes         int64  // Largest file size that may be read; zero means unlimited
+
+	// CommandExecutor runs the cat binary, e.g. a TimeoutCommandExecutor to bound
+	// its duration. Nil uses RealCommandExecutor.
+	CommandExecutor CommandExecutor
 }
 
 // NewCat creates a new instance of the Cat wrapper
 func NewCat(logger goai.Logger) *Cat {
+	return NewCatWithConfig(logger, CatConfig{})
+}
+
+// NewCatWithConfig creates a new instance of the Cat wrapper with the given configuration
+func NewCatWithConfig(logger goai.Logger, config CatConfig) *Cat {
 	return &Cat{
 		logger:      logger,
-		cmdExecutor: &RealCommandExecutor{},
+		cmdExecutor: commandExecutorOrDefault(config.CommandExecutor),
+		config:      config,
 	}
 }
 
 // CatAllInOneTool returns a goai.Tool that can execute cat commands
 func (c *Cat) CatAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(c.logger, goai.Tool{
 		Name:        CatToolName,
 		Description: "Display contents of files",
 		InputSchema: json.RawMessage(`{
@@ -46,45 +68,187 @@ func (c *Cat) CatAllInOneTool() goai.Tool {
                     "items": {
                         "type": "string"
                     },
-                    "description": "Additional cat options (e.g., -n for line numbers)"
+                    "description": "Additional cat options: -n, -b, -s, -E, -T or -A (e.g., -n for line numbers)"
                 }
             },
             "required": ["files"]
         }`),
 		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
 			var input struct {
 				Files   []string `json:"files"`
 				Options []string `json:"options"`
 			}
 
-			c.logger.WithFields(map[string]interface{}{"tool": CatToolName}).Info("Received input", "input", string(params.Arguments))
+			c.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
-				return goai.CallToolResult{}, fmt.Errorf("failed to parse input: %w", err)
+				span.RecordError(err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to parse input: %w", err))
 			}
 
 			if len(input.Files) == 0 {
-				c.logger.WithFields(map[string]interface{}{"tool": CatToolName}).Error("At least one file must be specified")
-				return returnErrorOutput(errors.New("at least one file must be specified")), nil
+				err := validationErrorf("at least one file must be specified")
+				c.logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+				}).Error("Cat input validation failed")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			if err := validateCatOptions(input.Options); err != nil {
+				c.logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+					"options":          input.Options,
+				}).Error("Option validation failed")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
 			}
 
-			c.logger.WithFields(map[string]interface{}{"tool": CatToolName}).Info("Total files to read", "total_files", len(input.Files))
+			if err := c.validateFiles(input.Files); err != nil {
+				c.logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+					"files":            input.Files,
+				}).Error("File validation failed")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
 
-			args := append(input.Options, input.Files...)
+			c.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"files":     input.Files,
+				"options":   input.Options,
+				"native":    c.config.UseNativeReader,
+			}).Info("Reading files")
 
-			c.logger.WithFields(map[string]interface{}{"tool": CatToolName}).Info("Executing cat command", "files", input.Files, "options", input.Options)
-			cmd := exec.Command("cat", args...)
-			output, err := c.cmdExecutor.ExecuteCommand(ctx, cmd)
+			var output []byte
+			var err error
+			if c.config.UseNativeReader {
+				output, err = readFilesNative(input.Files, input.Options)
+			} else {
+				// "--" keeps the validated files from being parsed as options
+				args := append(append(append([]string{}, input.Options...), "--"), input.Files...)
+				cmd := exec.Command("cat", args...)
+				output, err = c.cmdExecutor.ExecuteCommand(ctx, cmd)
+			}
 			if err != nil {
-				c.logger.WithFields(map[string]interface{}{"tool": CatToolName}).Error("Failed to execute cat command", "error", err)
+				c.logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+				}).Error("Failed to execute cat command")
+				span.RecordError(err)
 				return returnErrorOutput(err), nil
 			}
 
 			o := string(output)
-			c.logger.WithFields(map[string]interface{}{"tool": CatToolName, "output_length": len(o)}).Info("Successfully executed cat command")
+			span.SetAttributes(attribute.Int("output_length", len(o)))
+			c.logger.WithFields(map[string]interface{}{
+				"tool_name":     params.Name,
+				"output_length": len(o),
+			}).Info("Successfully executed cat command")
 			return goai.CallToolResult{
 				Content: []goai.ToolResultContent{{Type: "text", Text: o}},
 				IsError: false,
 			}, nil
 		},
+	})
+}
+
+// catAllowedFlags are the single letter cat flags accepted in options; they
+// may be combined, as in -nE
+const catAllowedFlags = "nbsETA"
+
+// validateCatOptions rejects everything but the known cat flags, so options
+// can never name a file outside the allowed directory
+func validateCatOptions(options []string) error {
+	for _, opt := range options {
+		if !strings.HasPrefix(opt, "-") {
+			return validationErrorf("invalid option %q: options must be flags", opt)
+		}
+		flags := strings.TrimPrefix(opt, "-")
+		if flags == "" || strings.Trim(flags, catAllowedFlags) != "" {
+			return validationErrorf("unsupported option: %s (allowed: -n, -b, -s, -E, -T, -A)", opt)
+		}
+	}
+	return nil
+}
+
+// validateFiles checks each file against the allowed directory and the maximum size
+func (c *Cat) validateFiles(files []string) error {
+	for _, file := range files {
+		allowed, err := isPathWithinDirectory(file, c.config.AllowedDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path %s: %w", file, err)
+		}
+		if !allowed {
+			return permissionErrorf("path outside allowed directory: %s", file)
+		}
+
+		if c.config.MaxBytes > 0 {
+			info, err := os.Stat(file)
+			if err != nil {
+				return err
+			}
+			if info.Size() > c.config.MaxBytes {
+				return permissionErrorf("file %s is %d bytes, exceeding the maximum of %d bytes", file, info.Size(), c.config.MaxBytes)
+			}
+		}
+	}
+	return nil
+}
+
+// readFilesNative concatenates the given files like cat, numbering all output
+// lines when the -n option is given
+func readFilesNative(files []string, options []string) ([]byte, error) {
+	numberLines := false
+	for _, opt := range options {
+		if opt != "-n" {
+			return nil, validationErrorf("unsupported option for native reader: %s", opt)
+		}
+		numberLines = true
+	}
+
+	var buf bytes.Buffer
+	for _, file := range files {
+		if err := copyFile(&buf, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if !numberLines || buf.Len() == 0 {
+		return buf.Bytes(), nil
+	}
+
+	var numbered bytes.Buffer
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		fmt.Fprintf(&numbered, "%6d\t%s", i+1, line)
 	}
+	return numbered.Bytes(), nil
+}
+
+// copyFile appends the contents of the named file to w
+func copyFile(w io.Writer, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
 }
@@ -0,0 +1,233 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runCatTool(t *testing.T, cat *Cat, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+
+	inputJSON, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := cat.CatAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      CatToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func newCatTestLogger() *MockLogger {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+	return mockLogger
+}
+
+func TestCat_NativeReader(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.txt")
+	require.NoError(t, os.WriteFile(first, []byte("alpha\nbeta\n"), 0644))
+	require.NoError(t, os.WriteFile(second, []byte("gamma"), 0644))
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+		isError  bool
+	}{
+		{
+			name:     "concatenates multiple files",
+			input:    map[string]interface{}{"files": []string{first, second}},
+			expected: "alpha\nbeta\ngamma",
+		},
+		{
+			name:     "numbers lines across files",
+			input:    map[string]interface{}{"files": []string{first, second}, "options": []string{"-n"}},
+			expected: "     1\talpha\n     2\tbeta\n     3\tgamma",
+		},
+		{
+			name:     "unsupported option",
+			input:    map[string]interface{}{"files": []string{first}, "options": []string{"-A"}},
+			expected: "unsupported option for native reader: -A",
+			isError:  true,
+		},
+		{
+			name:    "missing file",
+			input:   map[string]interface{}{"files": []string{filepath.Join(dir, "missing.txt")}},
+			isError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := NewCatWithConfig(newCatTestLogger(), CatConfig{UseNativeReader: true})
+
+			result := runCatTool(t, cat, tt.input)
+
+			assert.Equal(t, tt.isError, result.IsError)
+			if !tt.isError {
+				assert.Equal(t, tt.expected, result.Content[0].Text)
+			} else if tt.expected != "" {
+				assert.Contains(t, result.Content[0].Text, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCat_Validation(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.txt")
+	large := filepath.Join(dir, "large.txt")
+	require.NoError(t, os.WriteFile(small, []byte("tiny"), 0644))
+	require.NoError(t, os.WriteFile(large, make([]byte, 2048), 0644))
+
+	tests := []struct {
+		name          string
+		files         []string
+		expectedError string
+	}{
+		{
+			name:          "file outside allowed directory",
+			files:         []string{"/etc/shadow"},
+			expectedError: "path outside allowed directory: /etc/shadow",
+		},
+		{
+			name:          "relative path escaping allowed directory",
+			files:         []string{filepath.Join(dir, "..", "other.txt")},
+			expectedError: "path outside allowed directory",
+		},
+		{
+			name:          "oversized file",
+			files:         []string{small, large},
+			expectedError: "exceeding the maximum of 1024 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			cat := NewCatWithConfig(newCatTestLogger(), CatConfig{AllowedDirectory: dir, MaxBytes: 1024})
+			cat.cmdExecutor = mockExecutor
+
+			result := runCatTool(t, cat, map[string]interface{}{"files": tt.files})
+
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCat_ValidationAllowsFileWithinLimits(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.txt")
+	require.NoError(t, os.WriteFile(small, []byte("tiny"), 0644))
+
+	cat := NewCatWithConfig(newCatTestLogger(), CatConfig{UseNativeReader: true, AllowedDirectory: dir, MaxBytes: 1024})
+
+	result := runCatTool(t, cat, map[string]interface{}{"files": []string{small}})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, "tiny", result.Content[0].Text)
+}
+
+func TestCat_Tracing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+	cat := NewCatWithConfig(newCatTestLogger(), CatConfig{UseNativeReader: true})
+
+	ctx, recorder := newTracingTestContext(t)
+	inputJSON, err := json.Marshal(map[string]interface{}{"files": []string{path}})
+	require.NoError(t, err)
+	_, err = cat.CatAllInOneTool().Handler(ctx, goai.CallToolParams{Name: CatToolName, Arguments: inputJSON})
+	require.NoError(t, err)
+	assert.False(t, spanRecordedError(requireHandlerSpan(t, recorder, CatToolName)))
+
+	ctx, recorder = newTracingTestContext(t)
+	inputJSON, err = json.Marshal(map[string]interface{}{"files": []string{}})
+	require.NoError(t, err)
+	_, err = cat.CatAllInOneTool().Handler(ctx, goai.CallToolParams{Name: CatToolName, Arguments: inputJSON})
+	require.NoError(t, err)
+	assert.True(t, spanRecordedError(requireHandlerSpan(t, recorder, CatToolName)))
+}
+
+func TestCat_OptionValidation(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("hello"), 0644))
+
+	tests := []struct {
+		name          string
+		options       []string
+		expectedError string
+	}{
+		{
+			name:          "file passed as an option",
+			options:       []string{"/etc/shadow"},
+			expectedError: `invalid option "/etc/shadow": options must be flags`,
+		},
+		{
+			name:          "unknown flag",
+			options:       []string{"-v"},
+			expectedError: "unsupported option: -v",
+		},
+		{
+			name:          "long option",
+			options:       []string{"--number"},
+			expectedError: "unsupported option: --number",
+		},
+		{
+			name:          "bare dash",
+			options:       []string{"-"},
+			expectedError: "unsupported option: -",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			cat := NewCatWithConfig(newCatTestLogger(), CatConfig{AllowedDirectory: dir})
+			cat.cmdExecutor = mockExecutor
+
+			result := runCatTool(t, cat, map[string]interface{}{"files": []string{file}, "options": tt.options})
+
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCat_OptionsPrecedeEndOfOptions(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("hello"), 0644))
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return assert.ObjectsAreEqual([]string{"cat", "-nE", "--", file}, cmd.Args)
+	})).Return([]byte("     1\thello$"), nil)
+
+	cat := NewCatWithConfig(newCatTestLogger(), CatConfig{AllowedDirectory: dir})
+	cat.cmdExecutor = mockExecutor
+
+	result := runCatTool(t, cat, map[string]interface{}{"files": []string{file}, "options": []string{"-nE"}})
+
+	assert.False(t, result.IsError)
+	mockExecutor.AssertExpectations(t)
+}
@@ -1,7 +1,9 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"os/exec"
 )
 
@@ -10,9 +12,62 @@ type CommandExecutor interface {
 	ExecuteCommand(ctx context.Context, cmd *exec.Cmd) ([]byte, error)
 }
 
+// SeparateOutputExecutor interface for executing commands while keeping
+// stdout, stderr and the exit code apart
+type SeparateOutputExecutor interface {
+	ExecuteCommandSeparateOutput(ctx context.Context, cmd *exec.Cmd) (CommandResult, error)
+}
+
+// CommandResult holds the separated output and exit code of a command
+type CommandResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// CommandRunner is implemented by executors that can run a command whose
+// output writers have already been set by the caller, so output can be streamed
+type CommandRunner interface {
+	RunCommand(ctx context.Context, cmd *exec.Cmd) error
+}
+
+// commandExecutorOrDefault returns executor, or a RealCommandExecutor when it is nil
+func commandExecutorOrDefault(executor CommandExecutor) CommandExecutor {
+	if executor == nil {
+		return &RealCommandExecutor{}
+	}
+	return executor
+}
+
 // RealCommandExecutor implements CommandExecutor for real command execution
 type RealCommandExecutor struct{}
 
 func (e *RealCommandExecutor) ExecuteCommand(_ context.Context, cmd *exec.Cmd) ([]byte, error) {
 	return cmd.CombinedOutput()
 }
+
+// RunCommand runs the command using the output writers already set on it
+func (e *RealCommandExecutor) RunCommand(_ context.Context, cmd *exec.Cmd) error {
+	return cmd.Run()
+}
+
+// ExecuteCommandSeparateOutput runs the command capturing stdout and stderr separately.
+// A non-zero exit status is reported through CommandResult.ExitCode rather than as an error.
+func (e *RealCommandExecutor) ExecuteCommandSeparateOutput(_ context.Context, cmd *exec.Cmd) (CommandResult, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	result := CommandResult{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}
+
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		result.ExitCode = exitError.ExitCode()
+		return result, nil
+	}
+	return result, err
+}
@@ -0,0 +1,74 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// LimitedCommandExecutor wraps a CommandExecutor and caps the combined output
+// of a command at Max bytes, appending a truncation marker when the output is
+// larger. When Inner implements CommandRunner the output is streamed through a
+// capped buffer, so the excess is never held in memory. A nil Inner uses
+// RealCommandExecutor, and a Max of zero or less disables the limit. Tools opt
+// in through the CommandExecutor field of their configuration.
+type LimitedCommandExecutor struct {
+	Max   int64
+	Inner CommandExecutor
+}
+
+// ExecuteCommand runs the command through the inner executor, keeping at most Max bytes of output
+func (e *LimitedCommandExecutor) ExecuteCommand(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	inner := e.Inner
+	if inner == nil {
+		inner = &RealCommandExecutor{}
+	}
+	if e.Max <= 0 {
+		return inner.ExecuteCommand(ctx, cmd)
+	}
+
+	runner, ok := inner.(CommandRunner)
+	if !ok {
+		output, err := inner.ExecuteCommand(ctx, cmd)
+		if int64(len(output)) > e.Max {
+			output = append(output[:e.Max:e.Max], truncationMarker(int64(len(output)), e.Max)...)
+		}
+		return output, err
+	}
+
+	w := &cappedBuffer{max: e.Max}
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	err := runner.RunCommand(ctx, cmd)
+	output := w.buf
+	if w.total > e.Max {
+		output = append(output, truncationMarker(w.total, e.Max)...)
+	}
+	return output, err
+}
+
+// truncationMarker describes output that was cut off at max bytes
+func truncationMarker(total, max int64) string {
+	return fmt.Sprintf("\n[output truncated: %d of %d bytes shown]", max, total)
+}
+
+// cappedBuffer is an io.Writer that keeps the first max bytes written to it and
+// discards the rest while counting the total
+type cappedBuffer struct {
+	max   int64
+	total int64
+	buf   []byte
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.max - int64(len(b.buf)); remaining > 0 {
+		n := int64(len(p))
+		if n > remaining {
+			n = remaining
+		}
+		b.buf = append(b.buf, p[:n]...)
+	}
+	b.total += int64(len(p))
+	return len(p), nil
+}
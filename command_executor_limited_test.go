@@ -0,0 +1,89 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitedCommandExecutor(t *testing.T) {
+	tests := []struct {
+		name     string
+		inner    CommandExecutor
+		command  string
+		expected string
+	}{
+		{
+			name:     "streams and truncates large output",
+			inner:    nil,
+			command:  "yes | head -c 100000",
+			expected: "y\ny\ny\ny\ny\n\n[output truncated: 10 of 100000 bytes shown]",
+		},
+		{
+			name:     "streams through timeout executor",
+			inner:    &TimeoutCommandExecutor{Timeout: 5 * time.Second},
+			command:  "yes | head -c 100000",
+			expected: "y\ny\ny\ny\ny\n\n[output truncated: 10 of 100000 bytes shown]",
+		},
+		{
+			name:     "keeps output within the limit",
+			inner:    nil,
+			command:  "echo short",
+			expected: "short\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &LimitedCommandExecutor{Max: 10, Inner: tt.inner}
+
+			output, err := executor.ExecuteCommand(context.Background(), exec.Command("sh", "-c", tt.command))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(output))
+		})
+	}
+}
+
+func TestLimitedCommandExecutor_BufferingInner(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.Anything).Return([]byte("0123456789abcdef"), nil)
+
+	executor := &LimitedCommandExecutor{Max: 10, Inner: mockExecutor}
+
+	output, err := executor.ExecuteCommand(context.Background(), exec.Command("cat", "big.log"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789\n[output truncated: 10 of 16 bytes shown]", string(output))
+}
+
+func TestLimitedCommandExecutor_ThroughToolConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "big.log")
+	require.NoError(t, os.WriteFile(file, bytes.Repeat([]byte("y\n"), 50000), 0644))
+
+	cat := NewCatWithConfig(newCatTestLogger(), CatConfig{
+		CommandExecutor: &LimitedCommandExecutor{Max: 10},
+	})
+
+	inputJSON, err := json.Marshal(map[string]interface{}{"files": []string{file}})
+	require.NoError(t, err)
+
+	result, err := cat.CatAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      CatToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "y\ny\ny\ny\ny\n\n[output truncated: 10 of 100000 bytes shown]", result.Content[0].Text)
+}
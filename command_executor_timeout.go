@@ -0,0 +1,91 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// ErrCommandTimeout is returned by TimeoutCommandExecutor when a command exceeds its timeout
+var ErrCommandTimeout = errors.New("command timed out")
+
+// TimeoutCommandExecutor implements CommandExecutor and SeparateOutputExecutor,
+// killing the command's whole process group when it runs longer than Timeout.
+// Tools opt in through the CommandExecutor field of their configuration. A zero
+// Timeout only honors context cancellation.
+type TimeoutCommandExecutor struct {
+	Timeout time.Duration
+}
+
+// ExecuteCommand runs the command and returns its combined stdout and stderr
+func (e *TimeoutCommandExecutor) ExecuteCommand(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := e.run(ctx, cmd)
+	return output.Bytes(), err
+}
+
+// RunCommand runs the command using the output writers already set on it
+func (e *TimeoutCommandExecutor) RunCommand(ctx context.Context, cmd *exec.Cmd) error {
+	return e.run(ctx, cmd)
+}
+
+// ExecuteCommandSeparateOutput runs the command capturing stdout and stderr separately.
+// A non-zero exit status is reported through CommandResult.ExitCode rather than as an error.
+func (e *TimeoutCommandExecutor) ExecuteCommandSeparateOutput(ctx context.Context, cmd *exec.Cmd) (CommandResult, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := e.run(ctx, cmd)
+	result := CommandResult{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}
+
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		result.ExitCode = exitError.ExitCode()
+		return result, nil
+	}
+	return result, err
+}
+
+// run starts the command in its own process group and waits for it, killing
+// the group when the timeout elapses or the context is cancelled
+func (e *TimeoutCommandExecutor) run(ctx context.Context, cmd *exec.Cmd) error {
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if e.Timeout > 0 {
+		timer := time.NewTimer(e.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-timeout:
+		killProcessGroup(cmd)
+		<-done
+		return fmt.Errorf("%w after %s", ErrCommandTimeout, e.Timeout)
+	case <-ctx.Done():
+		killProcessGroup(cmd)
+		<-done
+		return ctx.Err()
+	}
+}
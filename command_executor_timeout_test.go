@@ -0,0 +1,86 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutCommandExecutor_KillsLongRunningCommand(t *testing.T) {
+	executor := &TimeoutCommandExecutor{Timeout: 100 * time.Millisecond}
+
+	// The background sleep keeps the output pipe open unless the whole process group is killed
+	cmd := exec.Command("sh", "-c", "echo started; sleep 5 & sleep 5; wait")
+
+	start := time.Now()
+	output, err := executor.ExecuteCommand(context.Background(), cmd)
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCommandTimeout))
+	assert.Equal(t, "started\n", string(output))
+}
+
+func TestTimeoutCommandExecutor_CompletesWithinTimeout(t *testing.T) {
+	executor := &TimeoutCommandExecutor{Timeout: 5 * time.Second}
+
+	output, err := executor.ExecuteCommand(context.Background(), exec.Command("sh", "-c", "echo out; echo err >&2"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "out\nerr\n", string(output))
+}
+
+func TestTimeoutCommandExecutor_SeparateOutput(t *testing.T) {
+	executor := &TimeoutCommandExecutor{Timeout: 5 * time.Second}
+
+	result, err := executor.ExecuteCommandSeparateOutput(context.Background(), exec.Command("sh", "-c", "echo out; echo err >&2; exit 2"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "out\n", string(result.Stdout))
+	assert.Equal(t, "err\n", string(result.Stderr))
+	assert.Equal(t, 2, result.ExitCode)
+}
+
+func TestTimeoutCommandExecutor_ContextCancelled(t *testing.T) {
+	executor := &TimeoutCommandExecutor{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := executor.ExecuteCommand(ctx, exec.Command("sleep", "5"))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTimeoutCommandExecutor_ThroughToolConfig(t *testing.T) {
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+	logger.On("Error", mock.Anything).Return()
+
+	bash := NewBashWithConfig(logger, BashConfig{
+		CommandExecutor: &TimeoutCommandExecutor{Timeout: 100 * time.Millisecond},
+	})
+
+	inputJSON, err := json.Marshal(map[string]interface{}{"command": "sleep 5 & sleep 5; wait"})
+	require.NoError(t, err)
+
+	start := time.Now()
+	result, err := bash.BashAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      BashToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, ErrCommandTimeout.Error())
+}
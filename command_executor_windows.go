@@ -0,0 +1,15 @@
+//go:build windows
+
+package mcptools
+
+import "os/exec"
+
+// setProcessGroup is a no-op on Windows, where only the command itself is killed
+func setProcessGroup(_ *exec.Cmd) {}
+
+// killProcessGroup kills the command's process
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
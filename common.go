@@ -1,9 +1,16 @@
 package mcptools
 
-import "github.com/shaharia-lab/goai"
+import (
+	"fmt"
+	"strings"
 
+	"github.com/shaharia-lab/goai"
+)
+
+// returnErrorOutput builds an error tool result from err. The kind of a
+// ToolError is appended as a second content item, see ResultErrorKind.
 func returnErrorOutput(err error) goai.CallToolResult {
-	return goai.CallToolResult{
+	result := goai.CallToolResult{
 		Content: []goai.ToolResultContent{
 			{
 				Type: "text",
@@ -12,4 +19,99 @@ func returnErrorOutput(err error) goai.CallToolResult {
 		},
 		IsError: true,
 	}
+
+	if kind, ok := ErrorKindOf(err); ok {
+		result.Content = append(result.Content, goai.ToolResultContent{
+			Type: "text",
+			Text: errorKindPrefix + string(kind),
+		})
+	}
+	return result
+}
+
+// isCommandBlocked reports whether the command matches an entry in blocked.
+// The first word of an entry is compared with the command and any further
+// words (e.g. "--hard" in "reset --hard") must each appear somewhere in args,
+// so reordering the arguments does not get past the entry. Matching is
+// case-insensitive and ignores repeated whitespace.
+func isCommandBlocked(blocked []string, command string, args []string) bool {
+	words := strings.Fields(strings.ToLower(command))
+	if len(words) == 0 {
+		return false
+	}
+	present := make(map[string]bool, len(words)+len(args))
+	for _, word := range words[1:] {
+		present[word] = true
+	}
+	for _, arg := range args {
+		present[normalizeCommand(arg)] = true
+	}
+
+	for _, entry := range blocked {
+		entryWords := strings.Fields(strings.ToLower(entry))
+		if len(entryWords) == 0 || entryWords[0] != words[0] {
+			continue
+		}
+		matched := true
+		for _, word := range entryWords[1:] {
+			if !present[word] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeCommand lowercases a command and collapses its whitespace
+func normalizeCommand(command string) string {
+	return strings.Join(strings.Fields(strings.ToLower(command)), " ")
+}
+
+// descriptionBuilder builds LLM-facing tool descriptions that include the
+// restrictions configured for the tool, so the model knows up front what it
+// may not do instead of discovering it through failed calls
+type descriptionBuilder struct {
+	base  string
+	notes []string
+}
+
+// newDescriptionBuilder starts a description from the tool's base text
+func newDescriptionBuilder(base string) *descriptionBuilder {
+	return &descriptionBuilder{base: base}
+}
+
+// restrictedTo notes the directory the tool is limited to. Empty directories are ignored.
+func (b *descriptionBuilder) restrictedTo(subject, dir string) *descriptionBuilder {
+	if dir != "" {
+		b.notes = append(b.notes, fmt.Sprintf("%s must be within %s", subject, dir))
+	}
+	return b
+}
+
+// blocked notes the values the tool refuses, e.g. commands or methods. Empty lists are ignored.
+func (b *descriptionBuilder) blocked(what string, values []string) *descriptionBuilder {
+	if len(values) > 0 {
+		b.notes = append(b.notes, fmt.Sprintf("Blocked %s: %s", what, strings.Join(values, ", ")))
+	}
+	return b
+}
+
+// allowed notes the only values the tool accepts, e.g. file patterns. Empty lists are ignored.
+func (b *descriptionBuilder) allowed(what string, values []string) *descriptionBuilder {
+	if len(values) > 0 {
+		b.notes = append(b.notes, fmt.Sprintf("Allowed %s: %s", what, strings.Join(values, ", ")))
+	}
+	return b
+}
+
+// String returns the base description followed by one sentence per restriction
+func (b *descriptionBuilder) String() string {
+	if len(b.notes) == 0 {
+		return b.base
+	}
+	return b.base + ". " + strings.Join(b.notes, ". ") + "."
 }
@@ -0,0 +1,87 @@
+package mcptools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTracingTestContext returns a context carrying a span from a recording
+// tracer provider, so spans started by tool handlers end up in the recorder
+func newTracingTestContext(t *testing.T) (context.Context, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "test")
+	t.Cleanup(func() { parent.End() })
+	return ctx, recorder
+}
+
+// requireHandlerSpan returns the ended span of the named tool's handler and
+// checks that it carries the tool_name attribute
+func requireHandlerSpan(t *testing.T, recorder *tracetest.SpanRecorder, toolName string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range recorder.Ended() {
+		if span.Name() == toolName+".Handler" {
+			assert.Contains(t, span.Attributes(), attribute.String("tool_name", toolName))
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span recorded for %s", toolName)
+	return nil
+}
+
+// spanRecordedError reports whether an error was recorded on the span
+func spanRecordedError(span sdktrace.ReadOnlySpan) bool {
+	for _, event := range span.Events() {
+		if event.Name == "exception" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDescriptionBuilder(t *testing.T) {
+	assert.Equal(t, "Base", newDescriptionBuilder("Base").restrictedTo("Paths", "").blocked("commands", nil).String())
+	assert.Equal(t, "Base. Paths must be within /data. Blocked commands: rm, push.",
+		newDescriptionBuilder("Base").restrictedTo("Paths", "/data").blocked("commands", []string{"rm", "push"}).String())
+}
+
+func TestToolDescriptionsIncludeConfiguredRestrictions(t *testing.T) {
+	logger := &MockLogger{}
+
+	fsTool := NewFileSystem(logger, FileSystemConfig{
+		AllowedDirectory: "/srv/data",
+		BlockedPatterns:  []string{"*.exe", "*.dll"},
+	}).FileSystemAllInOneTool()
+	assert.Contains(t, fsTool.Description, "/srv/data")
+	assert.Contains(t, fsTool.Description, "*.exe, *.dll")
+
+	allowlistTool := NewFileSystem(logger, FileSystemConfig{AllowedPatterns: []string{"*.md", "*.txt"}}).FileSystemAllInOneTool()
+	assert.Contains(t, allowlistTool.Description, "Allowed file patterns: *.md, *.txt")
+
+	curlTool := NewCurl(logger, CurlConfig{BlockedMethods: []string{"delete", "put"}}).CurlAllInOneTool()
+	assert.Contains(t, curlTool.Description, "Blocked HTTP methods: DELETE, PUT")
+
+	gitTool := NewGit(logger, GitConfig{
+		DefaultRepoPath: "/srv/repos",
+		BlockedCommands: []string{"push", "reset --hard"},
+	}).GitAllInOneTool()
+	assert.Contains(t, gitTool.Description, "/srv/repos")
+	assert.Contains(t, gitTool.Description, "Blocked git commands: push, reset --hard")
+
+	dockerTool := NewDockerWithConfig(logger, DockerConfig{BlockedCommands: []string{"rm", "system prune"}}).DockerAllInOneTool()
+	assert.Contains(t, dockerTool.Description, "Blocked docker commands: rm, system prune")
+
+	unrestricted := NewDocker(logger).DockerAllInOneTool()
+	assert.Equal(t, "Execute Docker commands with specified arguments", unrestricted.Description)
+}
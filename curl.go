@@ -1,12 +1,20 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,17 +24,107 @@ import (
 
 const CurlToolName = "curl"
 
+// curlDefaultMaxRedirects is the number of redirects followed when
+// follow_redirects is set without max_redirects
+const curlDefaultMaxRedirects = 10
+
+// curlFinalURLMarker separates the response body from the final URL that curl
+// prints through --write-out
+const curlFinalURLMarker = "\n__mcp_curl_final_url__:"
+
+// curlRequestInput holds the request fields of a curl tool call
+type curlRequestInput = struct {
+	URL      string            `json:"url"`
+	Method   string            `json:"method"`
+	Data     string            `json:"data"`
+	Headers  map[string]string `json:"headers"`
+	Insecure bool              `json:"insecure"`
+}
+
+// curlFormFile is a local file uploaded as a multipart/form-data part
+type curlFormFile struct {
+	Field string `json:"field"`
+	Path  string `json:"path"`
+}
+
+// curlForm holds the fields and files of a multipart/form-data request body
+type curlForm struct {
+	Fields map[string]string
+	Files  []curlFormFile
+}
+
+// empty reports whether the form has no fields and no files
+func (f curlForm) empty() bool {
+	return len(f.Fields) == 0 && len(f.Files) == 0
+}
+
+// sortedFieldNames returns the form field names in a stable order
+func (f curlForm) sortedFieldNames() []string {
+	names := make([]string, 0, len(f.Fields))
+	for name := range f.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// redirectPolicy controls whether and how far redirects are followed
+type redirectPolicy struct {
+	Follow bool
+	Max    int
+}
+
 // Curl represents a wrapper around the system's curl command-line tool,
 // providing a programmatic interface for making HTTP requests.
 type Curl struct {
 	logger         goai.Logger
 	blockedMethods []string
 	cmdExecutor    CommandExecutor
+	httpClient     *http.Client
+	config         CurlConfig
 }
 
 // CurlConfig holds the configuration for the Curl tool
 type CurlConfig struct {
 	BlockedMethods []string
+	// UseNativeHTTP performs requests with Go's net/http client instead of
+	// shelling out to the curl binary
+	UseNativeHTTP bool
+	// Timeout bounds the total duration of a request, including reading the
+	// response body. Zero means no timeout.
+	Timeout time.Duration
+	// MaxResponseBytes is the largest response body accepted; larger responses
+	// are rejected with an error. Zero means unlimited.
+	MaxResponseBytes int64
+	// AllowedDirectory restricts the local files that may be uploaded with the
+	// files input. Empty means any readable file may be uploaded.
+	AllowedDirectory string
+	// Retries is the number of times a request is retried after a connection
+	// error or a retryable status code. Zero disables retries.
+	Retries int
+	// RetryBackoff is the wait before the first retry; it doubles on every
+	// further retry. A Retry-After header takes precedence. The curl binary
+	// ignores it and uses its own backoff starting at one second.
+	RetryBackoff time.Duration
+	// RetryStatusCodes lists the status codes that are retried. When empty,
+	// 429, 502, 503 and 504 are retried. The curl binary ignores it and
+	// retries 408, 429, 500, 502, 503 and 504.
+	RetryStatusCodes []int
+	// RetryNonIdempotent also retries methods such as POST and PATCH, which
+	// may then be applied twice. By default only idempotent methods are retried.
+	RetryNonIdempotent bool
+	// CommandExecutor runs the curl binary when UseNativeHTTP is off, e.g. a
+	// TimeoutCommandExecutor to bound its duration. Nil uses RealCommandExecutor.
+	CommandExecutor CommandExecutor
+}
+
+// curlDefaultRetryStatusCodes are the transient status codes retried when
+// CurlConfig.RetryStatusCodes is empty
+var curlDefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
 }
 
 // NewCurl creates and returns a new instance of the Curl wrapper with the provided configuration.
@@ -39,7 +137,9 @@ func NewCurl(logger goai.Logger, config CurlConfig) *Curl {
 	return &Curl{
 		logger:         logger,
 		blockedMethods: blockedMethods,
-		cmdExecutor:    &RealCommandExecutor{},
+		cmdExecutor:    commandExecutorOrDefault(config.CommandExecutor),
+		httpClient:     &http.Client{},
+		config:         config,
 	}
 }
 
@@ -56,9 +156,11 @@ func (c *Curl) isMethodBlocked(method string) bool {
 
 // CurlAllInOneTool returns a goai.Tool that can perform various HTTP requests
 func (c *Curl) CurlAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(c.logger, goai.Tool{
 		Name:        CurlToolName,
-		Description: "Perform any HTTP request with specified method, URL, headers, and data",
+		Description: newDescriptionBuilder("Perform any HTTP request with specified method, URL, headers, and data").
+			blocked("HTTP methods", c.blockedMethods).
+			String(),
 		InputSchema: json.RawMessage(`{
         "type": "object",
         "properties": {
@@ -84,6 +186,39 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
             "insecure": {
                 "type": "boolean",
                 "description": "Allow insecure server connections when using SSL"
+            },
+            "form": {
+                "type": "object",
+                "description": "Form fields sent as a multipart/form-data body. Cannot be combined with data",
+                "additionalProperties": {
+                    "type": "string"
+                }
+            },
+            "files": {
+                "type": "array",
+                "description": "Local files uploaded as multipart/form-data parts. Cannot be combined with data",
+                "items": {
+                    "type": "object",
+                    "properties": {
+                        "field": {
+                            "type": "string",
+                            "description": "Form field name of the file part"
+                        },
+                        "path": {
+                            "type": "string",
+                            "description": "Path of the local file to upload"
+                        }
+                    },
+                    "required": ["field", "path"]
+                }
+            },
+            "follow_redirects": {
+                "type": "boolean",
+                "description": "Follow HTTP redirects and report the final URL. Redirects are not followed by default"
+            },
+            "max_redirects": {
+                "type": "integer",
+                "description": "Maximum number of redirects to follow when follow_redirects is set (default 10)"
             }
         },
         "required": ["url", "method"]
@@ -101,11 +236,11 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
 			}).Info("Received input")
 
 			var input struct {
-				URL      string            `json:"url"`
-				Method   string            `json:"method"`
-				Data     string            `json:"data"`
-				Headers  map[string]string `json:"headers"`
-				Insecure bool              `json:"insecure"`
+				curlRequestInput
+				Form            map[string]string `json:"form"`
+				Files           []curlFormFile    `json:"files"`
+				FollowRedirects bool              `json:"follow_redirects"`
+				MaxRedirects    int               `json:"max_redirects"`
 			}
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -115,11 +250,11 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
 				}).Error("Failed to unmarshal input parameters")
 
 				span.RecordError(err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to parse input: %w", err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to parse input: %w", err))
 			}
 
 			// In your Handler function, add validation before command execution:
-			if err := validateInput(input); err != nil {
+			if err := validateInput(input.curlRequestInput); err != nil {
 				c.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
 				}).Error("Input validation failed")
@@ -127,9 +262,18 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
 				return returnErrorOutput(err), nil
 			}
 
+			form := curlForm{Fields: input.Form, Files: input.Files}
+			if err := c.validateForm(input.Data, form); err != nil {
+				c.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+				}).Error("Form validation failed")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
 			// Check blocked methods after basic validation
 			if c.isMethodBlocked(input.Method) {
-				err := fmt.Errorf("HTTP method %s is blocked", input.Method)
+				err := permissionErrorf("HTTP method %s is blocked", input.Method)
 				c.logger.WithFields(map[string]interface{}{
 					"method": input.Method,
 					"url":    input.URL,
@@ -141,6 +285,7 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
 			// Validate URL
 			parsedURL, err := url.Parse(input.URL)
 			if err != nil {
+				err = newToolError(ErrorKindValidation, err)
 				c.logger.WithFields(map[string]interface{}{
 					"url":                       input.URL,
 					goai.ErrorLogField: err,
@@ -163,33 +308,38 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
 				input.Headers[key] = os.ExpandEnv(value)
 			}
 
-			// Build curl command arguments
-			args := []string{"-s", "-X", strings.ToUpper(input.Method)}
-			if input.Insecure {
-				args = append(args, "-k")
+			if c.config.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+				defer cancel()
 			}
 
-			for key, value := range input.Headers {
-				args = append(args, "-H", fmt.Sprintf("%s: %s", key, value))
+			redirects := redirectPolicy{Follow: input.FollowRedirects, Max: input.MaxRedirects}
+			if redirects.Max <= 0 {
+				redirects.Max = curlDefaultMaxRedirects
 			}
 
-			if input.Data != "" {
-				args = append(args, "-d", input.Data)
+			var output []byte
+			var finalURL string
+			if c.config.UseNativeHTTP {
+				c.logger.WithFields(map[string]interface{}{
+					"method":        input.Method,
+					"url":           input.URL,
+					"headers_count": len(input.Headers),
+					"has_data":      input.Data != "",
+					"has_form":      !form.empty(),
+					"insecure":      input.Insecure,
+				}).Info("Executing HTTP request")
+
+				output, finalURL, err = c.doHTTPRequest(ctx, input.Method, input.URL, input.Data, form, input.Headers, input.Insecure, redirects)
+			} else {
+				output, finalURL, err = c.executeCurlCommand(ctx, input.Method, input.URL, input.Data, form, input.Headers, input.Insecure, redirects)
 			}
 
-			args = append(args, input.URL)
-
-			c.logger.WithFields(map[string]interface{}{
-				"method":        input.Method,
-				"url":           input.URL,
-				"headers_count": len(input.Headers),
-				"has_data":      input.Data != "",
-				"insecure":      input.Insecure,
-			}).Info("Executing curl command")
-
-			// Execute the command
-			cmd := exec.CommandContext(ctx, "curl", args...)
-			output, err := c.cmdExecutor.ExecuteCommand(ctx, cmd)
+			if err == nil && c.config.MaxResponseBytes > 0 && int64(len(output)) > c.config.MaxResponseBytes {
+				err = validationErrorf("response exceeds maximum size of %d bytes", c.config.MaxResponseBytes)
+			}
+			err = newToolError(ErrorKindUpstream, err)
 
 			// Log execution results
 			executionTime := time.Since(startTime)
@@ -218,38 +368,298 @@ func (c *Curl) CurlAllInOneTool() goai.Tool {
 				"output_length": len(output),
 			}).Info("Curl command executed successfully")
 
-			return goai.CallToolResult{
-				Content: []goai.ToolResultContent{
-					{
-						Type: "text",
-						Text: string(output),
-					},
+			content := []goai.ToolResultContent{
+				{
+					Type: "text",
+					Text: string(output),
 				},
-			}, nil
+			}
+			if input.FollowRedirects {
+				content = append(content, goai.ToolResultContent{
+					Type: "text",
+					Text: fmt.Sprintf("final_url: %s", finalURL),
+				})
+			}
+
+			return goai.CallToolResult{Content: content}, nil
 		},
+	})
+}
+
+// executeCurlCommand performs the request by shelling out to the curl binary and
+// returns the response body and the URL it was finally served from
+func (c *Curl) executeCurlCommand(ctx context.Context, method, rawURL, data string, form curlForm, headers map[string]string, insecure bool, redirects redirectPolicy) ([]byte, string, error) {
+	// Build curl command arguments
+	args := []string{"-s", "-X", strings.ToUpper(method)}
+	if c.config.Timeout > 0 {
+		args = append(args, "--max-time", strconv.FormatFloat(c.config.Timeout.Seconds(), 'f', -1, 64))
+	}
+	if c.config.MaxResponseBytes > 0 {
+		args = append(args, "--max-filesize", strconv.FormatInt(c.config.MaxResponseBytes, 10))
+	}
+	if insecure {
+		args = append(args, "-k")
+	}
+	if redirects.Follow {
+		args = append(args, "-L", "--max-redirs", strconv.Itoa(redirects.Max), "-w", curlFinalURLMarker+"%{url_effective}")
+	}
+	if c.config.Retries > 0 && (idempotentMethods[strings.ToUpper(method)] || c.config.RetryNonIdempotent) {
+		// curl retries timeouts and transient 408, 429 and 5xx responses, honoring Retry-After
+		args = append(args, "--retry", strconv.Itoa(c.config.Retries), "--retry-connrefused")
+	}
+
+	for key, value := range headers {
+		args = append(args, "-H", fmt.Sprintf("%s: %s", key, value))
+	}
+
+	if data != "" {
+		// --data-raw keeps curl from reading a file when data starts with @
+		args = append(args, "--data-raw", data)
+	}
+
+	// --form-string keeps curl from treating values starting with @ or < as file references
+	for _, name := range form.sortedFieldNames() {
+		args = append(args, "--form-string", fmt.Sprintf("%s=%s", name, form.Fields[name]))
+	}
+	for _, file := range form.Files {
+		// The quoted path keeps commas and semicolons from being parsed as more files or part options
+		args = append(args, "-F", fmt.Sprintf("%s=@%s", file.Field, curlQuote(file.Path)))
+	}
+
+	args = append(args, rawURL)
+
+	c.logger.WithFields(map[string]interface{}{
+		"method":        method,
+		"url":           rawURL,
+		"headers_count": len(headers),
+		"has_data":      data != "",
+		"has_form":      !form.empty(),
+		"insecure":      insecure,
+	}).Info("Executing curl command")
+
+	// Execute the command
+	cmd := exec.CommandContext(ctx, "curl", args...)
+	output, err := c.cmdExecutor.ExecuteCommand(ctx, cmd)
+	if err != nil || !redirects.Follow {
+		return output, rawURL, err
+	}
+
+	idx := bytes.LastIndex(output, []byte(curlFinalURLMarker))
+	if idx < 0 {
+		return output, rawURL, nil
 	}
+	return output[:idx], string(output[idx+len(curlFinalURLMarker):]), nil
 }
 
-func validateInput(input struct {
-	URL      string            `json:"url"`
-	Method   string            `json:"method"`
-	Data     string            `json:"data"`
-	Headers  map[string]string `json:"headers"`
-	Insecure bool              `json:"insecure"`
-}) error {
+// doHTTPRequest performs the request with net/http and returns the response body and
+// the URL it was finally served from. Like curl, redirects are only followed when the
+// policy allows it. Responses with a status code other than 2xx, or 3xx when redirects
+// are not followed, are reported as errors that include the body.
+func (c *Curl) doHTTPRequest(ctx context.Context, method, rawURL, data string, form curlForm, headers map[string]string, insecure bool, redirects redirectPolicy) ([]byte, string, error) {
+	var body []byte
+	var formContentType string
+	if data != "" {
+		body = []byte(data)
+	} else if !form.empty() {
+		var err error
+		body, formContentType, err = buildMultipartBody(form)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// The request is rebuilt for every attempt so the body can be replayed on retries
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), rawURL, reader)
+		if err != nil {
+			return nil, newToolError(ErrorKindValidation, fmt.Errorf("failed to create request: %w", err))
+		}
+
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		if data != "" && req.Header.Get("Content-Type") == "" {
+			// Match curl's default for -d
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		if formContentType != "" {
+			// The boundary is generated with the body, so it always overrides the header
+			req.Header.Set("Content-Type", formContentType)
+		}
+		return req, nil
+	}
+
+	client := *c.httpClient
+	if insecure {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- explicitly requested by the caller
+		client.Transport = transport
+	}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !redirects.Follow {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > redirects.Max {
+			return fmt.Errorf("stopped after %d redirects", redirects.Max)
+		}
+		return nil
+	}
+
+	resp, err := retryDo(ctx, &client, newRequest, c.retryConfig())
+	if err != nil {
+		if _, ok := ErrorKindOf(err); ok {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	reader := io.Reader(resp.Body)
+	if c.config.MaxResponseBytes > 0 {
+		// Read one extra byte so oversized responses can be detected
+		reader = io.LimitReader(resp.Body, c.config.MaxResponseBytes+1)
+	}
+
+	respBody, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	isRedirect := resp.StatusCode >= 300 && resp.StatusCode <= 399 && !redirects.Follow
+	if (resp.StatusCode < 200 || resp.StatusCode > 299) && !isRedirect {
+		return nil, "", fmt.Errorf("request returned status %s: %s", resp.Status, string(respBody))
+	}
+
+	return respBody, resp.Request.URL.String(), nil
+}
+
+// retryConfig returns the retry settings for native HTTP requests
+func (c *Curl) retryConfig() RetryConfig {
+	statusCodes := c.config.RetryStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = curlDefaultRetryStatusCodes
+	}
+	return RetryConfig{
+		MaxAttempts:          c.config.Retries + 1,
+		BaseDelay:            c.config.RetryBackoff,
+		RetryableStatusCodes: statusCodes,
+		RetryNonIdempotent:   c.config.RetryNonIdempotent,
+	}
+}
+
+// buildMultipartBody encodes the form as a multipart/form-data body and returns
+// it along with the Content-Type header carrying its boundary
+func buildMultipartBody(form curlForm) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, name := range form.sortedFieldNames() {
+		if err := writer.WriteField(name, form.Fields[name]); err != nil {
+			return nil, "", fmt.Errorf("failed to write form field %s: %w", name, err)
+		}
+	}
+
+	for _, file := range form.Files {
+		if err := addMultipartFile(writer, file); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to finish multipart body: %w", err)
+	}
+
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// addMultipartFile copies a local file into a new part of the multipart body
+func addMultipartFile(writer *multipart.Writer, file curlFormFile) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", file.Path, err)
+	}
+	defer f.Close()
+
+	part, err := writer.CreateFormFile(file.Field, filepath.Base(file.Path))
+	if err != nil {
+		return fmt.Errorf("failed to create form file %s: %w", file.Field, err)
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// validateForm rejects forms combined with a raw data body, incomplete file
+// entries and files outside the allowed directory
+func (c *Curl) validateForm(data string, form curlForm) error {
+	if form.empty() {
+		return nil
+	}
+	if data != "" {
+		return validationErrorf("data cannot be combined with form or files")
+	}
+
+	for _, name := range form.sortedFieldNames() {
+		if err := validateCurlFormFieldName(name); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range form.Files {
+		if file.Field == "" || file.Path == "" {
+			return validationErrorf("each file requires a field and a path")
+		}
+		if err := validateCurlFormFieldName(file.Field); err != nil {
+			return err
+		}
+		if c.config.AllowedDirectory == "" {
+			continue
+		}
+
+		allowed, err := isPathWithinDirectory(file.Path, c.config.AllowedDirectory)
+		if err != nil {
+			return newToolError(ErrorKindValidation, err)
+		}
+		if !allowed {
+			return permissionErrorf("file %s is outside the allowed directory", file.Path)
+		}
+	}
+	return nil
+}
+
+// validateCurlFormFieldName rejects the characters curl's -F syntax gives a
+// meaning to, which would let a field name add file parts or part options
+func validateCurlFormFieldName(name string) error {
+	if strings.ContainsAny(name, `=;",`) {
+		return validationErrorf("invalid form field name %q: must not contain '=', ';', '\"' or ','", name)
+	}
+	return nil
+}
+
+// curlQuote double-quotes value for curl's -F syntax, escaping quotes and backslashes
+func curlQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func validateInput(input curlRequestInput) error {
 	// Check required fields first
 	if input.Method == "" {
-		return fmt.Errorf("method is required")
+		return validationErrorf("method is required")
 	}
 
 	if input.URL == "" {
-		return fmt.Errorf("url is required")
+		return validationErrorf("url is required")
 	}
 
 	// Validate URL format
 	_, err := url.Parse(input.URL)
 	if err != nil {
-		return fmt.Errorf("invalid URL: %w", err)
+		return validationErrorf("invalid URL: %w", err)
 	}
 
 	return nil
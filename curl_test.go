@@ -3,8 +3,17 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"github.com/stretchr/testify/assert"
@@ -162,3 +171,536 @@ func TestCurl_CurlAllInOneTool(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 	mockExecutor.AssertExpectations(t)
 }
+
+func TestCurl_NativeHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/get":
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case "/post":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"name":"test"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		input       map[string]interface{}
+		expectError bool
+		expected    string
+	}{
+		{
+			name: "GET request",
+			input: map[string]interface{}{
+				"url":     server.URL + "/get",
+				"method":  "GET",
+				"headers": map[string]string{"Authorization": "Bearer token"},
+			},
+			expected: `{"status":"ok"}`,
+		},
+		{
+			name: "POST request with body",
+			input: map[string]interface{}{
+				"url":     server.URL + "/post",
+				"method":  "post",
+				"data":    `{"name":"test"}`,
+				"headers": map[string]string{"Content-Type": "application/json"},
+			},
+			expected: `{"id":1}`,
+		},
+		{
+			name: "non-2xx response",
+			input: map[string]interface{}{
+				"url":    server.URL + "/missing",
+				"method": "GET",
+			},
+			expectError: true,
+			expected:    "404 Not Found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			curl := NewCurl(mockLogger, CurlConfig{UseNativeHTTP: true})
+			mockExecutor := new(MockCommandExecutor)
+			curl.cmdExecutor = mockExecutor
+
+			inputJSON, err := json.Marshal(tt.input)
+			assert.NoError(t, err)
+
+			result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      CurlToolName,
+				Arguments: inputJSON,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectError, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCurl_NativeHTTPLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+			_, _ = w.Write([]byte("too late"))
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		config   CurlConfig
+		path     string
+		expected string
+		kind     ErrorKind
+	}{
+		{
+			name:     "request exceeding timeout",
+			config:   CurlConfig{UseNativeHTTP: true, Timeout: 50 * time.Millisecond},
+			path:     "/slow",
+			expected: "context deadline exceeded",
+			kind:     ErrorKindUpstream,
+		},
+		{
+			name:     "response exceeding max size",
+			config:   CurlConfig{UseNativeHTTP: true, MaxResponseBytes: 10},
+			path:     "/large",
+			expected: "response exceeds maximum size of 10 bytes",
+			kind:     ErrorKindValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			curl := NewCurl(mockLogger, tt.config)
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"url":    server.URL + tt.path,
+				"method": "GET",
+			})
+			assert.NoError(t, err)
+
+			result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      CurlToolName,
+				Arguments: inputJSON,
+			})
+
+			assert.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+			kind, ok := ResultErrorKind(result)
+			assert.True(t, ok)
+			assert.Equal(t, tt.kind, kind)
+		})
+	}
+}
+
+func TestCurl_NativeHTTPRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = fmt.Fprintf(w, "%s %s", r.Method, body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		config        CurlConfig
+		method        string
+		expectedCalls int32
+		expected      string
+		expectError   bool
+	}{
+		{
+			name:          "idempotent request retried after 503",
+			config:        CurlConfig{UseNativeHTTP: true, Retries: 2, RetryBackoff: time.Millisecond},
+			method:        "PUT",
+			expectedCalls: 2,
+			expected:      "PUT payload",
+		},
+		{
+			name:          "non-idempotent request not retried by default",
+			config:        CurlConfig{UseNativeHTTP: true, Retries: 2, RetryBackoff: time.Millisecond},
+			method:        "POST",
+			expectedCalls: 1,
+			expected:      "request returned status 503",
+			expectError:   true,
+		},
+		{
+			name:          "non-idempotent request retried when allowed",
+			config:        CurlConfig{UseNativeHTTP: true, Retries: 2, RetryBackoff: time.Millisecond, RetryNonIdempotent: true},
+			method:        "POST",
+			expectedCalls: 2,
+			expected:      "POST payload",
+		},
+		{
+			name:          "no retries configured",
+			config:        CurlConfig{UseNativeHTTP: true},
+			method:        "GET",
+			expectedCalls: 1,
+			expected:      "request returned status 503",
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+			curl := NewCurl(mockLogger, tt.config)
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"url":    server.URL,
+				"method": tt.method,
+				"data":   "payload",
+			})
+			assert.NoError(t, err)
+
+			result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      CurlToolName,
+				Arguments: inputJSON,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectError, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestCurl_NativeHTTPRedirects(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/start":
+			http.Redirect(w, r, server.URL+"/final", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, server.URL+"/loop", http.StatusFound)
+		case "/final":
+			_, _ = w.Write([]byte("arrived"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name             string
+		input            map[string]interface{}
+		expectError      string
+		expectedBody     string
+		expectedFinalURL string
+	}{
+		{
+			name:             "follows redirect and reports final URL",
+			input:            map[string]interface{}{"url": server.URL + "/start", "method": "GET", "follow_redirects": true},
+			expectedBody:     "arrived",
+			expectedFinalURL: server.URL + "/final",
+		},
+		{
+			name:         "does not follow redirects by default",
+			input:        map[string]interface{}{"url": server.URL + "/start", "method": "GET"},
+			expectedBody: "<a href=\"" + server.URL + "/final\">Found</a>.\n\n",
+		},
+		{
+			name:        "stops after max redirects",
+			input:       map[string]interface{}{"url": server.URL + "/loop", "method": "GET", "follow_redirects": true, "max_redirects": 2},
+			expectError: "stopped after 2 redirects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			curl := NewCurl(mockLogger, CurlConfig{UseNativeHTTP: true})
+
+			inputJSON, err := json.Marshal(tt.input)
+			assert.NoError(t, err)
+
+			result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      CurlToolName,
+				Arguments: inputJSON,
+			})
+			assert.NoError(t, err)
+
+			if tt.expectError != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, result.Content[0].Text, tt.expectError)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			assert.Equal(t, tt.expectedBody, result.Content[0].Text)
+			if tt.expectedFinalURL == "" {
+				assert.Len(t, result.Content, 1)
+				return
+			}
+			if assert.Len(t, result.Content, 2) {
+				assert.Equal(t, "final_url: "+tt.expectedFinalURL, result.Content[1].Text)
+			}
+		})
+	}
+}
+
+func TestCurl_CommandRedirects(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		args := strings.Join(cmd.Args, " ")
+		return strings.Contains(args, " -L --max-redirs 3 -w ")
+	})).Return([]byte("arrived"+curlFinalURLMarker+"https://example.com/final"), nil)
+
+	curl := NewCurl(mockLogger, CurlConfig{})
+	curl.cmdExecutor = mockExecutor
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"url":              "https://example.com/start",
+		"method":           "GET",
+		"follow_redirects": true,
+		"max_redirects":    3,
+	})
+	assert.NoError(t, err)
+
+	result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      CurlToolName,
+		Arguments: inputJSON,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	if assert.Len(t, result.Content, 2) {
+		assert.Equal(t, "arrived", result.Content[0].Text)
+		assert.Equal(t, "final_url: https://example.com/final", result.Content[1].Text)
+	}
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestCurl_NativeHTTPMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("upload")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		fmt.Fprintf(w, "%s|%s|%s", r.FormValue("title"), header.Filename, content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	uploadPath := filepath.Join(dir, "report.txt")
+	assert.NoError(t, os.WriteFile(uploadPath, []byte("file body"), 0644))
+
+	tests := []struct {
+		name        string
+		config      CurlConfig
+		input       map[string]interface{}
+		wantErr     bool
+		errContains string
+		wantText    string
+	}{
+		{
+			name:   "uploads form field and file",
+			config: CurlConfig{UseNativeHTTP: true, AllowedDirectory: dir},
+			input: map[string]interface{}{
+				"url":    server.URL,
+				"method": "POST",
+				"form":   map[string]string{"title": "quarterly"},
+				"files":  []map[string]string{{"field": "upload", "path": uploadPath}},
+			},
+			wantText: "quarterly|report.txt|file body",
+		},
+		{
+			name:   "rejects file outside allowed directory",
+			config: CurlConfig{UseNativeHTTP: true, AllowedDirectory: t.TempDir()},
+			input: map[string]interface{}{
+				"url":    server.URL,
+				"method": "POST",
+				"files":  []map[string]string{{"field": "upload", "path": uploadPath}},
+			},
+			wantErr:     true,
+			errContains: "outside the allowed directory",
+		},
+		{
+			name:   "rejects file field name with part options",
+			config: CurlConfig{UseNativeHTTP: true, AllowedDirectory: dir},
+			input: map[string]interface{}{
+				"url":    server.URL,
+				"method": "POST",
+				"files":  []map[string]string{{"field": "a=@/etc/shadow;filename=x", "path": uploadPath}},
+			},
+			wantErr:     true,
+			errContains: "invalid form field name",
+		},
+		{
+			name:   "rejects form field name with a comma",
+			config: CurlConfig{UseNativeHTTP: true},
+			input: map[string]interface{}{
+				"url":    server.URL,
+				"method": "POST",
+				"form":   map[string]string{"a,b": "value"},
+			},
+			wantErr:     true,
+			errContains: "invalid form field name",
+		},
+		{
+			name:   "rejects form combined with data",
+			config: CurlConfig{UseNativeHTTP: true},
+			input: map[string]interface{}{
+				"url":    server.URL,
+				"method": "POST",
+				"data":   "raw",
+				"form":   map[string]string{"title": "quarterly"},
+			},
+			wantErr:     true,
+			errContains: "data cannot be combined with form or files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			curl := NewCurl(mockLogger, tt.config)
+
+			inputJSON, err := json.Marshal(tt.input)
+			assert.NoError(t, err)
+
+			result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      CurlToolName,
+				Arguments: inputJSON,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			if tt.wantErr {
+				assert.Contains(t, result.Content[0].Text, tt.errContains)
+				return
+			}
+			assert.Equal(t, tt.wantText, result.Content[0].Text)
+		})
+	}
+}
+
+func TestCurl_CommandMultipart(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		args := strings.Join(cmd.Args, " ")
+		return strings.Contains(args, ` --form-string title=@notafile -F upload=@"/tmp/report.txt" `)
+	})).Return([]byte("uploaded"), nil)
+
+	curl := NewCurl(mockLogger, CurlConfig{})
+	curl.cmdExecutor = mockExecutor
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"url":    "https://example.com/upload",
+		"method": "POST",
+		"form":   map[string]string{"title": "@notafile"},
+		"files":  []map[string]string{{"field": "upload", "path": "/tmp/report.txt"}},
+	})
+	assert.NoError(t, err)
+
+	result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      CurlToolName,
+		Arguments: inputJSON,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, "uploaded", result.Content[0].Text)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestCurl_CommandDataIsRaw(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		args := strings.Join(cmd.Args, " ")
+		return strings.Contains(args, " --data-raw @/etc/shadow ") && !strings.Contains(args, " -d ")
+	})).Return([]byte("ok"), nil)
+
+	curl := NewCurl(mockLogger, CurlConfig{AllowedDirectory: t.TempDir()})
+	curl.cmdExecutor = mockExecutor
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"url":    "https://example.com/submit",
+		"method": "POST",
+		"data":   "@/etc/shadow",
+	})
+	assert.NoError(t, err)
+
+	result, err := curl.CurlAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      CurlToolName,
+		Arguments: inputJSON,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestCurlQuote(t *testing.T) {
+	assert.Equal(t, `"/tmp/a,b;c.txt"`, curlQuote("/tmp/a,b;c.txt"))
+	assert.Equal(t, `"/tmp/say \"hi\" \\ bye"`, curlQuote(`/tmp/say "hi" \ bye`))
+}
@@ -3,35 +3,115 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 )
 
 const DockerToolName = "docker"
 
+// dockerCommandPattern matches docker subcommand names. Anything else, in
+// particular a global option such as -H, would let the arguments run another
+// subcommand past the blocked commands.
+var dockerCommandPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// dockerGlobalFlags are the docker options that point the CLI at another daemon
+// or configuration, which no argument may set
+var dockerGlobalFlags = []string{"-H", "--host", "--context", "--config"}
+
 // Docker represents a wrapper around the system's docker command-line tool
 type Docker struct {
 	logger      goai.Logger
 	cmdExecutor CommandExecutor
+	config      DockerConfig
+
+	engineClient    dockerEngineClient
+	engineClientErr error
 }
 
-// Rest of your existing struct definitions...
+// dockerJSONFormatCommands lists the docker commands that support --format '{{json .}}'
+var dockerJSONFormatCommands = map[string]bool{
+	"ps":      true,
+	"images":  true,
+	"inspect": true,
+}
+
+// dockerShortForms maps the verbs of the container and image management
+// commands to the top-level command doing the same, e.g. "container rm" to "rm"
+var dockerShortForms = map[string]map[string]string{
+	"container": {
+		"attach": "attach", "commit": "commit", "cp": "cp", "create": "create",
+		"diff": "diff", "exec": "exec", "export": "export", "kill": "kill",
+		"logs": "logs", "ls": "ps", "list": "ps", "ps": "ps", "pause": "pause",
+		"port": "port", "rename": "rename", "restart": "restart", "rm": "rm",
+		"remove": "rm", "run": "run", "start": "start", "stats": "stats",
+		"stop": "stop", "top": "top", "unpause": "unpause", "update": "update",
+		"wait": "wait",
+	},
+	"image": {
+		"build": "build", "history": "history", "import": "import", "load": "load",
+		"ls": "images", "list": "images", "pull": "pull", "push": "push",
+		"rm": "rmi", "remove": "rmi", "save": "save", "tag": "tag",
+	},
+}
+
+// dockerCommandInput holds the command and arguments of a Docker tool call
+type dockerCommandInput = struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// DockerConfig holds the configuration for the Docker tool
+type DockerConfig struct {
+	// BlockedCommands lists docker commands that may not be executed. Entries are
+	// matched case-insensitively against the command (e.g. "rm") or the command
+	// with arguments it is given in any position (e.g. "system prune"). A
+	// container or image management command and its short form (e.g.
+	// "container rm" and "rm", or "image rm" and "rmi") are the same entry, so
+	// listing either blocks both.
+	BlockedCommands []string
+	// Timeout bounds the duration of a single docker command, so commands such as
+	// "logs -f" cannot block forever. Zero means no timeout.
+	Timeout time.Duration
+	// UseEngineAPI serves ps, images, logs and inspect through the Docker Engine
+	// API instead of the docker CLI, returning structured JSON. Other commands
+	// still use the CLI.
+	UseEngineAPI bool
+	// CommandExecutor runs the docker CLI, e.g. a TimeoutCommandExecutor to
+	// bound its duration. Nil uses RealCommandExecutor.
+	CommandExecutor CommandExecutor
+}
 
 // NewDocker creates and returns a new instance of the Docker wrapper
 func NewDocker(logger goai.Logger) *Docker {
-	return &Docker{
+	return NewDockerWithConfig(logger, DockerConfig{})
+}
+
+// NewDockerWithConfig creates and returns a new instance of the Docker wrapper with the provided configuration.
+func NewDockerWithConfig(logger goai.Logger, config DockerConfig) *Docker {
+	d := &Docker{
 		logger:      logger,
-		cmdExecutor: &RealCommandExecutor{},
+		cmdExecutor: commandExecutorOrDefault(config.CommandExecutor),
+		config:      config,
+	}
+	if config.UseEngineAPI {
+		d.engineClient, d.engineClientErr = newDockerEngineClient()
 	}
+	return d
 }
 
 // DockerAllInOneTool returns a goai.Tool that can execute Docker commands
 func (d *Docker) DockerAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(d.logger, goai.Tool{
 		Name:        DockerToolName,
-		Description: "Execute Docker commands with specified arguments",
+		Description: newDescriptionBuilder("Execute Docker commands with specified arguments").
+			blocked("docker commands", d.config.BlockedCommands).
+			String(),
 		InputSchema: json.RawMessage(`{
             "type": "object",
             "properties": {
@@ -45,6 +125,10 @@ func (d *Docker) DockerAllInOneTool() goai.Tool {
                         "type": "string"
                     },
                     "description": "Arguments for the Docker command"
+                },
+                "json": {
+                    "type": "boolean",
+                    "description": "Return a JSON array of objects for commands that support it (ps, images, inspect). Always on when the server uses the Docker Engine API"
                 }
             },
             "required": ["command"]
@@ -54,8 +138,8 @@ func (d *Docker) DockerAllInOneTool() goai.Tool {
 			defer span.End()
 
 			var input struct {
-				Command string   `json:"command"`
-				Args    []string `json:"args"`
+				dockerCommandInput
+				JSON bool `json:"json"`
 			}
 
 			d.logger.WithFields(map[string]interface{}{
@@ -68,10 +152,10 @@ func (d *Docker) DockerAllInOneTool() goai.Tool {
 					"raw_input":                 string(params.Arguments),
 				}).Error("Failed to unmarshal input parameters")
 				span.RecordError(err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to parse input: %w", err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to parse input: %w", err))
 			}
 
-			if err := validateDockerInput(input); err != nil {
+			if err := validateDockerInput(input.dockerCommandInput); err != nil {
 				d.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
 				}).Error("Input validation failed")
@@ -79,9 +163,76 @@ func (d *Docker) DockerAllInOneTool() goai.Tool {
 				return returnErrorOutput(err), nil
 			}
 
+			if isDockerCommandBlocked(d.config.BlockedCommands, input.Command, input.Args) {
+				err := permissionErrorf("docker command '%s' is blocked", input.Command)
+				d.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"command":          input.Command,
+				}).Error("Blocked docker command")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			if err := validateDockerCommand(input.Command, input.Args); err != nil {
+				d.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"command":          input.Command,
+				}).Error("Invalid docker command")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
 			// Create the command with plain text output format
-			args := append([]string{input.Command}, input.Args...)
-			cmd := exec.Command("docker", args...)
+			if d.config.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, d.config.Timeout)
+				defer cancel()
+			}
+
+			if d.config.UseEngineAPI && dockerEngineCommands[strings.ToLower(input.Command)] {
+				d.logger.WithFields(map[string]interface{}{
+					"tool":    DockerToolName,
+					"command": input.Command,
+					"args":    input.Args,
+				}).Info("Executing docker command through the engine API")
+
+				output, err := d.runEngineCommand(ctx, strings.ToLower(input.Command), input.Args)
+				if err != nil {
+					if d.config.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						err = upstreamErrorf("docker command timed out after %s", d.config.Timeout)
+					}
+					d.logger.WithFields(map[string]interface{}{
+						goai.ErrorLogField: err,
+						"command":          input.Command,
+						"args":             input.Args,
+					}).Error("Docker engine API request failed")
+					span.RecordError(err)
+					return returnErrorOutput(newToolError(ErrorKindUpstream, err)), nil
+				}
+
+				return goai.CallToolResult{
+					Content: []goai.ToolResultContent{{Type: "text", Text: string(output)}},
+					IsError: false,
+				}, nil
+			}
+
+			args := []string{input.Command}
+			jsonOutput := input.JSON && dockerJSONFormatCommands[strings.ToLower(input.Command)]
+			if jsonOutput {
+				if err := validateDockerFormatArgs(input.Command, input.Args); err != nil {
+					d.logger.WithFields(map[string]interface{}{
+						goai.ErrorLogField: err,
+						"command":          input.Command,
+					}).Error("Docker arguments conflict with JSON output")
+					span.RecordError(err)
+					return returnErrorOutput(err), nil
+				}
+				// The format goes right after the subcommand, so it cannot end up
+				// after a "--" or among the arguments of the container to run
+				args = append(args, "--format", "{{json .}}")
+			}
+			args = append(args, input.Args...)
+			cmd := exec.CommandContext(ctx, "docker", args...)
 
 			d.logger.WithFields(map[string]interface{}{
 				"tool": DockerToolName,
@@ -90,19 +241,40 @@ func (d *Docker) DockerAllInOneTool() goai.Tool {
 			// Execute the command using the executor
 			output, err := d.cmdExecutor.ExecuteCommand(ctx, cmd)
 			if err != nil {
+				if d.config.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					err = upstreamErrorf("docker command timed out after %s", d.config.Timeout)
+				}
 				d.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
 					"command":                   "docker",
 					"args":                      args,
 				}).Error("Docker command execution failed")
 				span.RecordError(err)
-				return returnErrorOutput(err), nil
+				return returnErrorOutput(newToolError(ErrorKindUpstream, err)), nil
 			}
 
 			d.logger.WithFields(map[string]interface{}{
 				"tool": DockerToolName,
 			}).Info("Docker command executed successfully", "command", input.Command, "args", input.Args)
 
+			if jsonOutput {
+				objects, err := parseDockerJSONLines(output)
+				if err != nil {
+					span.RecordError(err)
+					return returnErrorOutput(err), nil
+				}
+
+				return goai.CallToolResult{
+					Content: []goai.ToolResultContent{
+						{
+							Type: "text",
+							Text: string(objects),
+						},
+					},
+					IsError: false,
+				}, nil
+			}
+
 			return goai.CallToolResult{
 				Content: []goai.ToolResultContent{
 					{
@@ -113,15 +285,92 @@ func (d *Docker) DockerAllInOneTool() goai.Tool {
 				IsError: false,
 			}, nil
 		},
-	}
+	})
 }
 
-func validateDockerInput(input struct {
-	Command string   `json:"command"`
-	Args    []string `json:"args"`
-}) error {
+func validateDockerInput(input dockerCommandInput) error {
 	if input.Command == "" {
-		return fmt.Errorf("command is required")
+		return validationErrorf("command is required")
+	}
+	return nil
+}
+
+// validateDockerCommand rejects commands that are not subcommand names and
+// arguments setting a global option, either of which could run a blocked
+// command or reach another daemon
+func validateDockerCommand(command string, args []string) error {
+	if !dockerCommandPattern.MatchString(command) {
+		return validationErrorf("invalid docker command '%s': must be a subcommand name such as ps or images", command)
+	}
+	for _, arg := range args {
+		for _, flag := range dockerGlobalFlags {
+			// -H takes its value attached as well, as in -Hunix:///var/run/docker.sock
+			if arg == flag || strings.HasPrefix(arg, flag+"=") || (flag == "-H" && strings.HasPrefix(arg, flag)) {
+				return permissionErrorf("docker option not allowed: %s", arg)
+			}
+		}
 	}
 	return nil
 }
+
+// validateDockerFormatArgs rejects a format given in the arguments, which
+// would replace the JSON format the output is parsed with. inspect also takes
+// the format as -f.
+func validateDockerFormatArgs(command string, args []string) error {
+	for _, arg := range args {
+		if arg == "--format" || strings.HasPrefix(arg, "--format=") ||
+			(strings.EqualFold(command, "inspect") && strings.HasPrefix(arg, "-f")) {
+			return validationErrorf("%s cannot be combined with json output for docker %s", arg, command)
+		}
+	}
+	return nil
+}
+
+// isDockerCommandBlocked is isCommandBlocked for docker commands, matching a
+// container or image management command and its short form alike
+func isDockerCommandBlocked(blocked []string, command string, args []string) bool {
+	canonical := make([]string, len(blocked))
+	for i, entry := range blocked {
+		canonical[i] = dockerShortForm(entry)
+	}
+
+	if isCommandBlocked(canonical, command, args) {
+		return true
+	}
+	if len(args) > 0 {
+		// "container rm x" is also checked as "rm x"
+		if short := dockerShortForm(command + " " + args[0]); short != normalizeCommand(command+" "+args[0]) {
+			return isCommandBlocked(canonical, short, args[1:])
+		}
+	}
+	return false
+}
+
+// dockerShortForm replaces a leading container or image management command
+// and its verb with the equivalent short command, e.g. "image rm" with "rmi"
+func dockerShortForm(command string) string {
+	fields := strings.Fields(strings.ToLower(command))
+	if len(fields) >= 2 {
+		if short, ok := dockerShortForms[fields[0]][fields[1]]; ok {
+			fields = append([]string{short}, fields[2:]...)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// parseDockerJSONLines converts the line-delimited JSON objects printed by
+// --format '{{json .}}' into a single JSON array
+func parseDockerJSONLines(output []byte) ([]byte, error) {
+	objects := []json.RawMessage{}
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !json.Valid([]byte(line)) {
+			return nil, upstreamErrorf("failed to parse docker JSON output: %s", line)
+		}
+		objects = append(objects, json.RawMessage(line))
+	}
+	return json.Marshal(objects)
+}
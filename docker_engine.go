@@ -0,0 +1,184 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// dockerEngineClient is the subset of the Docker Engine API client used by the
+// Docker tool, so tests can substitute a fake
+type dockerEngineClient interface {
+	ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error)
+	ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error)
+	ContainerLogs(ctx context.Context, container string, options container.LogsOptions) (io.ReadCloser, error)
+	ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error)
+}
+
+// dockerEngineCommands lists the commands served by the Engine API when it is enabled.
+// All other commands still go through the docker CLI.
+var dockerEngineCommands = map[string]bool{
+	"ps":      true,
+	"images":  true,
+	"logs":    true,
+	"inspect": true,
+}
+
+// dockerLogs is the result of the logs command when served by the Engine API
+type dockerLogs struct {
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr"`
+}
+
+// newDockerEngineClient connects to the daemon configured through the standard
+// DOCKER_HOST, DOCKER_API_VERSION, DOCKER_CERT_PATH and DOCKER_TLS_VERIFY variables
+func newDockerEngineClient() (dockerEngineClient, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker engine client: %w", err)
+	}
+	return cli, nil
+}
+
+// runEngineCommand serves a read-only docker command through the Engine API and
+// returns its result as JSON
+func (d *Docker) runEngineCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	if d.engineClientErr != nil {
+		return nil, d.engineClientErr
+	}
+
+	var result interface{}
+	var err error
+	switch command {
+	case "ps":
+		result, err = d.engineListContainers(ctx, args)
+	case "images":
+		result, err = d.engineListImages(ctx, args)
+	case "logs":
+		result, err = d.engineContainerLogs(ctx, args)
+	case "inspect":
+		result, err = d.engineInspectContainers(ctx, args)
+	default:
+		return nil, validationErrorf("docker command '%s' is not supported by the engine API", command)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+func (d *Docker) engineListContainers(ctx context.Context, args []string) ([]types.Container, error) {
+	options := container.ListOptions{}
+	for _, arg := range args {
+		switch arg {
+		case "-a", "--all":
+			options.All = true
+		default:
+			return nil, validationErrorf("unsupported argument %q for ps", arg)
+		}
+	}
+	return d.engineClient.ContainerList(ctx, options)
+}
+
+func (d *Docker) engineListImages(ctx context.Context, args []string) ([]image.Summary, error) {
+	options := image.ListOptions{}
+	for _, arg := range args {
+		switch arg {
+		case "-a", "--all":
+			options.All = true
+		default:
+			return nil, validationErrorf("unsupported argument %q for images", arg)
+		}
+	}
+	return d.engineClient.ImageList(ctx, options)
+}
+
+func (d *Docker) engineContainerLogs(ctx context.Context, args []string) (dockerLogs, error) {
+	options := container.LogsOptions{ShowStdout: true, ShowStderr: true}
+	var containers []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case "-t", "--timestamps":
+			options.Timestamps = true
+		case "-n", "--tail", "--since", "--until":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return dockerLogs{}, validationErrorf("argument %s requires a value", name)
+				}
+				i++
+				value = args[i]
+			}
+			switch name {
+			case "--since":
+				options.Since = value
+			case "--until":
+				options.Until = value
+			default:
+				options.Tail = value
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return dockerLogs{}, validationErrorf("unsupported argument %q for logs", arg)
+			}
+			containers = append(containers, arg)
+		}
+	}
+	if len(containers) != 1 {
+		return dockerLogs{}, validationErrorf("logs requires exactly one container")
+	}
+
+	info, err := d.engineClient.ContainerInspect(ctx, containers[0])
+	if err != nil {
+		return dockerLogs{}, err
+	}
+
+	reader, err := d.engineClient.ContainerLogs(ctx, containers[0], options)
+	if err != nil {
+		return dockerLogs{}, err
+	}
+	defer reader.Close()
+
+	var stdout, stderr bytes.Buffer
+	if info.Config != nil && info.Config.Tty {
+		// TTY containers have a single raw stream
+		_, err = io.Copy(&stdout, reader)
+	} else {
+		_, err = stdcopy.StdCopy(&stdout, &stderr, reader)
+	}
+	if err != nil {
+		return dockerLogs{}, fmt.Errorf("failed to read container logs: %w", err)
+	}
+
+	return dockerLogs{Stdout: stdout.String(), Stderr: stderr.String()}, nil
+}
+
+func (d *Docker) engineInspectContainers(ctx context.Context, args []string) ([]types.ContainerJSON, error) {
+	if len(args) == 0 {
+		return nil, validationErrorf("inspect requires at least one container")
+	}
+
+	results := make([]types.ContainerJSON, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return nil, validationErrorf("unsupported argument %q for inspect", arg)
+		}
+		info, err := d.engineClient.ContainerInspect(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, info)
+	}
+	return results, nil
+}
@@ -0,0 +1,176 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDockerEngineClient is a mock implementation of dockerEngineClient
+type MockDockerEngineClient struct {
+	mock.Mock
+}
+
+func (m *MockDockerEngineClient) ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error) {
+	args := m.Called(ctx, options)
+	return args.Get(0).([]types.Container), args.Error(1)
+}
+
+func (m *MockDockerEngineClient) ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error) {
+	args := m.Called(ctx, options)
+	return args.Get(0).([]image.Summary), args.Error(1)
+}
+
+func (m *MockDockerEngineClient) ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error) {
+	args := m.Called(ctx, containerID, options)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockDockerEngineClient) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	args := m.Called(ctx, containerID)
+	return args.Get(0).(types.ContainerJSON), args.Error(1)
+}
+
+func newEngineDocker(engine dockerEngineClient) (*Docker, *MockCommandExecutor) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	docker := NewDocker(mockLogger)
+	docker.config.UseEngineAPI = true
+	docker.engineClient = engine
+	mockExecutor := new(MockCommandExecutor)
+	docker.cmdExecutor = mockExecutor
+	return docker, mockExecutor
+}
+
+func runDockerTool(t *testing.T, docker *Docker, command string, args ...string) goai.CallToolResult {
+	t.Helper()
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"command": command,
+		"args":    args,
+	})
+	require.NoError(t, err)
+
+	result, err := docker.DockerAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      DockerToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestDocker_EngineAPIPs(t *testing.T) {
+	engine := new(MockDockerEngineClient)
+	engine.On("ContainerList", mock.Anything, container.ListOptions{All: true}).Return([]types.Container{
+		{ID: "abc123", Image: "nginx", Names: []string{"/web"}},
+	}, nil)
+
+	docker, executor := newEngineDocker(engine)
+	result := runDockerTool(t, docker, "ps", "-a")
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var containers []types.Container
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &containers))
+	require.Len(t, containers, 1)
+	assert.Equal(t, "abc123", containers[0].ID)
+	engine.AssertExpectations(t)
+	executor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+}
+
+func TestDocker_EngineAPIImages(t *testing.T) {
+	engine := new(MockDockerEngineClient)
+	engine.On("ImageList", mock.Anything, image.ListOptions{}).Return([]image.Summary{
+		{ID: "sha256:1", RepoTags: []string{"nginx:latest"}},
+	}, nil)
+
+	docker, _ := newEngineDocker(engine)
+	result := runDockerTool(t, docker, "images")
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var images []image.Summary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &images))
+	require.Len(t, images, 1)
+	assert.Equal(t, []string{"nginx:latest"}, images[0].RepoTags)
+}
+
+func TestDocker_EngineAPILogs(t *testing.T) {
+	var stream bytes.Buffer
+	_, err := stdcopy.NewStdWriter(&stream, stdcopy.Stdout).Write([]byte("started\n"))
+	require.NoError(t, err)
+	_, err = stdcopy.NewStdWriter(&stream, stdcopy.Stderr).Write([]byte("warning\n"))
+	require.NoError(t, err)
+
+	engine := new(MockDockerEngineClient)
+	engine.On("ContainerInspect", mock.Anything, "web").Return(types.ContainerJSON{Config: &container.Config{}}, nil)
+	engine.On("ContainerLogs", mock.Anything, "web", container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       "10",
+	}).Return(io.NopCloser(&stream), nil)
+
+	docker, _ := newEngineDocker(engine)
+	result := runDockerTool(t, docker, "logs", "--tail", "10", "web")
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var logs dockerLogs
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &logs))
+	assert.Equal(t, "started\n", logs.Stdout)
+	assert.Equal(t, "warning\n", logs.Stderr)
+	engine.AssertExpectations(t)
+}
+
+func TestDocker_EngineAPIInspect(t *testing.T) {
+	engine := new(MockDockerEngineClient)
+	engine.On("ContainerInspect", mock.Anything, "web").Return(types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: "abc123", Name: "/web"},
+	}, nil)
+
+	docker, _ := newEngineDocker(engine)
+	result := runDockerTool(t, docker, "inspect", "web")
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var containers []types.ContainerJSON
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &containers))
+	require.Len(t, containers, 1)
+	assert.Equal(t, "abc123", containers[0].ID)
+}
+
+func TestDocker_EngineAPIRejectsUnsupportedArguments(t *testing.T) {
+	engine := new(MockDockerEngineClient)
+	docker, _ := newEngineDocker(engine)
+
+	result := runDockerTool(t, docker, "logs", "-f", "web")
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, `unsupported argument "-f" for logs`, result.Content[0].Text)
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindValidation, kind)
+	engine.AssertNotCalled(t, "ContainerLogs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDocker_EngineAPIFallsBackToCLI(t *testing.T) {
+	engine := new(MockDockerEngineClient)
+	docker, executor := newEngineDocker(engine)
+	executor.On("ExecuteCommand", mock.Anything, mock.Anything).Return([]byte("Client: Docker Engine\n"), nil)
+
+	result := runDockerTool(t, docker, "version")
+
+	require.False(t, result.IsError)
+	assert.Equal(t, "Client: Docker Engine\n", result.Content[0].Text)
+	executor.AssertExpectations(t)
+}
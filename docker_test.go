@@ -3,11 +3,17 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDocker(t *testing.T) {
@@ -146,3 +152,261 @@ func TestDocker_ValidateDockerInput(t *testing.T) {
 		})
 	}
 }
+
+func TestDocker_BlockedCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		blocked bool
+	}{
+		{name: "blocked rm", command: "rm", args: []string{"my-container"}, blocked: true},
+		{name: "blocked rm with different case", command: "RM", blocked: true},
+		{name: "blocked subcommand", command: "system", args: []string{"prune", "-f"}, blocked: true},
+		{name: "allowed ps", command: "ps", args: []string{"-a"}, blocked: false},
+		{name: "allowed system info", command: "system", args: []string{"info"}, blocked: false},
+		{name: "blocked management form", command: "container", args: []string{"rm", "my-container"}, blocked: true},
+		{name: "blocked management form alias", command: "Container", args: []string{"remove"}, blocked: true},
+		{name: "blocked exec management form", command: "container", args: []string{"exec", "web", "sh"}, blocked: true},
+		{name: "blocked short form of listed management form", command: "images", blocked: true},
+		{name: "blocked listed management form", command: "image", args: []string{"list"}, blocked: true},
+		{name: "allowed image rm", command: "image", args: []string{"rm", "nginx"}, blocked: false},
+		{name: "allowed container ls", command: "container", args: []string{"ls"}, blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			mockExecutor := new(MockCommandExecutor)
+			mockExecutor.On("ExecuteCommand", mock.Anything, mock.Anything).Return([]byte("mock docker output"), nil)
+
+			docker := NewDockerWithConfig(mockLogger, DockerConfig{
+				BlockedCommands: []string{"Rm", "system  prune", "exec", "image ls"},
+			})
+			docker.cmdExecutor = mockExecutor
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command": tt.command,
+				"args":    tt.args,
+			})
+			assert.NoError(t, err)
+
+			result, err := docker.DockerAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      DockerToolName,
+				Arguments: inputJSON,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.blocked, result.IsError)
+			if tt.blocked {
+				assert.Contains(t, result.Content[0].Text, "is blocked")
+				mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+			} else {
+				mockExecutor.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestDocker_RejectsGlobalOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		command       string
+		args          []string
+		expectedError string
+	}{
+		{name: "host as command", command: "-H", args: []string{"unix:///var/run/docker.sock", "rm", "-f", "x"}, expectedError: "invalid docker command '-H'"},
+		{name: "debug as command", command: "--debug", args: []string{"rm", "-f", "x"}, expectedError: "invalid docker command '--debug'"},
+		{name: "host in args", command: "ps", args: []string{"-H", "tcp://10.0.0.1:2375"}, expectedError: "docker option not allowed: -H"},
+		{name: "attached host in args", command: "ps", args: []string{"-Htcp://10.0.0.1:2375"}, expectedError: "docker option not allowed: -Htcp://10.0.0.1:2375"},
+		{name: "long host in args", command: "ps", args: []string{"--host=tcp://10.0.0.1:2375"}, expectedError: "docker option not allowed: --host="},
+		{name: "context in args", command: "ps", args: []string{"--context", "prod"}, expectedError: "docker option not allowed: --context"},
+		{name: "config in args", command: "ps", args: []string{"--config", "/tmp/docker"}, expectedError: "docker option not allowed: --config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			mockExecutor := new(MockCommandExecutor)
+			docker := NewDockerWithConfig(mockLogger, DockerConfig{
+				BlockedCommands: []string{"rm"},
+				CommandExecutor: mockExecutor,
+			})
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command": tt.command,
+				"args":    tt.args,
+			})
+			require.NoError(t, err)
+
+			result, err := docker.DockerAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      DockerToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDockerShortForm(t *testing.T) {
+	assert.Equal(t, "rm my-container", dockerShortForm("container rm my-container"))
+	assert.Equal(t, "rmi", dockerShortForm("Image  RM"))
+	assert.Equal(t, "ps", dockerShortForm("container ls"))
+	assert.Equal(t, "container prune", dockerShortForm("container prune"))
+	assert.Equal(t, "volume rm", dockerShortForm("volume rm"))
+}
+
+func TestDocker_Timeout(t *testing.T) {
+	// A fake docker binary that hangs like "docker logs -f"
+	binDir := t.TempDir()
+	script := "#!/bin/sh\nexec sleep 5\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "docker"), []byte(script), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	docker := NewDockerWithConfig(mockLogger, DockerConfig{Timeout: 100 * time.Millisecond})
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"command": "logs",
+		"args":    []string{"-f", "my-container"},
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	result, err := docker.DockerAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      DockerToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "docker command timed out after 100ms")
+}
+
+func TestDocker_JSONOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		command      string
+		output       string
+		expectedArgs []string
+		expectJSON   bool
+	}{
+		{
+			name:         "ps with json",
+			command:      "ps",
+			output:       "{\"ID\":\"abc123\",\"Image\":\"nginx\",\"Names\":\"web\"}\n{\"ID\":\"def456\",\"Image\":\"redis\",\"Names\":\"cache\"}\n",
+			expectedArgs: []string{"docker", "ps", "--format", "{{json .}}", "-a"},
+			expectJSON:   true,
+		},
+		{
+			name:         "unsupported command keeps raw output",
+			command:      "version",
+			output:       "Client: Docker Engine\n",
+			expectedArgs: []string{"docker", "version", "-a"},
+			expectJSON:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+
+			mockExecutor := new(MockCommandExecutor)
+			mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+				return assert.ObjectsAreEqual(tt.expectedArgs, cmd.Args)
+			})).Return([]byte(tt.output), nil)
+
+			docker := NewDocker(mockLogger)
+			docker.cmdExecutor = mockExecutor
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command": tt.command,
+				"args":    []string{"-a"},
+				"json":    true,
+			})
+			require.NoError(t, err)
+
+			result, err := docker.DockerAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      DockerToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			mockExecutor.AssertExpectations(t)
+
+			if !tt.expectJSON {
+				assert.Equal(t, tt.output, result.Content[0].Text)
+				return
+			}
+
+			var containers []map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &containers))
+			require.Len(t, containers, 2)
+			assert.Equal(t, "abc123", containers[0]["ID"])
+			assert.Equal(t, "cache", containers[1]["Names"])
+		})
+	}
+}
+
+func TestDocker_JSONOutputRejectsFormatArgs(t *testing.T) {
+	tests := []struct {
+		command string
+		args    []string
+	}{
+		{command: "ps", args: []string{"--format", "{{.ID}}"}},
+		{command: "images", args: []string{"--format={{.Repository}}"}},
+		{command: "inspect", args: []string{"-f", "{{.State}}", "web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command+" "+strings.Join(tt.args, " "), func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", []interface{}{"Docker arguments conflict with JSON output"}).Return()
+
+			mockExecutor := new(MockCommandExecutor)
+			docker := NewDockerWithConfig(mockLogger, DockerConfig{CommandExecutor: mockExecutor})
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command": tt.command,
+				"args":    tt.args,
+				"json":    true,
+			})
+			require.NoError(t, err)
+
+			result, err := docker.DockerAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      DockerToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, "cannot be combined with json output")
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package mcptools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// ErrorKind classifies why a tool call failed
+type ErrorKind string
+
+const (
+	// ErrorKindValidation means the tool input was malformed or incomplete
+	ErrorKindValidation ErrorKind = "validation"
+	// ErrorKindPermission means the configuration forbids the requested action,
+	// e.g. a blocked command or a path outside the allowed directory
+	ErrorKindPermission ErrorKind = "permission"
+	// ErrorKindNotFound means the requested resource does not exist
+	ErrorKindNotFound ErrorKind = "not_found"
+	// ErrorKindUpstream means a command, API or database the tool relies on failed
+	ErrorKindUpstream ErrorKind = "upstream"
+	// ErrorKindInternal means the tool itself failed, e.g. while encoding its result
+	ErrorKindInternal ErrorKind = "internal"
+)
+
+// errorKindPrefix introduces the error kind appended to error tool results
+const errorKindPrefix = "error_kind: "
+
+// ToolError is an error returned by a tool together with its kind, so callers
+// can tell bad input from forbidden actions and failing dependencies:
+//
+//	var toolErr *ToolError
+//	if errors.As(err, &toolErr) && toolErr.Kind == ErrorKindValidation { ... }
+type ToolError struct {
+	Kind ErrorKind
+	Err  error
+}
+
+// Error returns the message of the wrapped error
+func (e *ToolError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *ToolError) Unwrap() error {
+	return e.Err
+}
+
+// newToolError wraps err with the given kind. Errors that already carry a
+// kind keep it, and a nil err stays nil.
+func newToolError(kind ErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := ErrorKindOf(err); ok {
+		return err
+	}
+	return &ToolError{Kind: kind, Err: err}
+}
+
+// validationErrorf formats a validation error
+func validationErrorf(format string, args ...interface{}) error {
+	return newToolError(ErrorKindValidation, fmt.Errorf(format, args...))
+}
+
+// permissionErrorf formats a permission error
+func permissionErrorf(format string, args ...interface{}) error {
+	return newToolError(ErrorKindPermission, fmt.Errorf(format, args...))
+}
+
+// upstreamErrorf formats an upstream error
+func upstreamErrorf(format string, args ...interface{}) error {
+	return newToolError(ErrorKindUpstream, fmt.Errorf(format, args...))
+}
+
+// ErrorKindOf returns the kind of the first ToolError in err's chain
+func ErrorKindOf(err error) (ErrorKind, bool) {
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) {
+		return toolErr.Kind, true
+	}
+	return "", false
+}
+
+// ResultErrorKind returns the error kind recorded in an error tool result
+func ResultErrorKind(result goai.CallToolResult) (ErrorKind, bool) {
+	if !result.IsError {
+		return "", false
+	}
+	for _, content := range result.Content {
+		if kind, found := strings.CutPrefix(content.Text, errorKindPrefix); found {
+			return ErrorKind(kind), true
+		}
+	}
+	return "", false
+}
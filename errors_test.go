@@ -0,0 +1,138 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToolError(t *testing.T) {
+	assert.NoError(t, newToolError(ErrorKindUpstream, nil))
+
+	base := errors.New("boom")
+	err := newToolError(ErrorKindUpstream, base)
+	assert.Equal(t, "boom", err.Error())
+	assert.ErrorIs(t, err, base)
+
+	kind, ok := ErrorKindOf(err)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindUpstream, kind)
+
+	// Wrapping an already classified error keeps its original kind
+	wrapped := newToolError(ErrorKindUpstream, fmt.Errorf("context: %w", permissionErrorf("denied")))
+	kind, ok = ErrorKindOf(wrapped)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindPermission, kind)
+
+	_, ok = ErrorKindOf(base)
+	assert.False(t, ok)
+}
+
+func TestReturnErrorOutput_ErrorKind(t *testing.T) {
+	result := returnErrorOutput(validationErrorf("path is required"))
+	assert.Equal(t, []goai.ToolResultContent{
+		{Type: "text", Text: "path is required"},
+		{Type: "text", Text: "error_kind: validation"},
+	}, result.Content)
+
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindValidation, kind)
+
+	result = returnErrorOutput(errors.New("unclassified"))
+	assert.Len(t, result.Content, 1)
+	_, ok = ResultErrorKind(result)
+	assert.False(t, ok)
+}
+
+func TestCurl_ErrorKinds(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	curl := NewCurl(mockLogger, CurlConfig{BlockedMethods: []string{"DELETE"}})
+	tool := curl.CurlAllInOneTool()
+
+	_, err := tool.Handler(context.Background(), goai.CallToolParams{
+		Name:      CurlToolName,
+		Arguments: []byte(`{"url": `),
+	})
+	var toolErr *ToolError
+	require.True(t, errors.As(err, &toolErr))
+	assert.Equal(t, ErrorKindValidation, toolErr.Kind)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{
+		Name:      CurlToolName,
+		Arguments: []byte(`{"url": "https://api.example.com", "method": "DELETE"}`),
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindPermission, kind)
+}
+
+func TestFileSystem_ErrorKinds(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(existing, []byte("one\n"), 0644))
+
+	tool := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: dir}).FileSystemAllInOneTool()
+
+	tests := []struct {
+		name         string
+		arguments    string
+		expectedKind ErrorKind
+	}{
+		{
+			name:         "missing file",
+			arguments:    fmt.Sprintf(`{"operation": "read", "path": %q}`, filepath.Join(dir, "missing.txt")),
+			expectedKind: ErrorKindNotFound,
+		},
+		{
+			name:         "read_lines range",
+			arguments:    fmt.Sprintf(`{"operation": "read_lines", "path": %q, "start_line": 5}`, existing),
+			expectedKind: ErrorKindValidation,
+		},
+		{
+			name:         "touch time",
+			arguments:    fmt.Sprintf(`{"operation": "touch", "path": %q, "time": "yesterday"}`, existing),
+			expectedKind: ErrorKindValidation,
+		},
+		{
+			name:         "malformed input",
+			arguments:    `{"operation": `,
+			expectedKind: ErrorKindValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: []byte(tt.arguments),
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedKind, kind)
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package mcptools
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,10 +18,24 @@ import (
 
 	"github.com/shaharia-lab/goai"
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 )
 
 const FileSystemToolName = "filesystem"
 
+// readLinesMaxLineLength is the longest line the read_lines operation accepts
+const readLinesMaxLineLength = 1024 * 1024
+
+// fileSystemSearchConcurrency bounds the number of files read concurrently by the search operation
+const fileSystemSearchConcurrency = 8
+
+// searchCandidate is a file whose name matches the search pattern, and whether
+// its content matched as well
+type searchCandidate struct {
+	path    string
+	matched bool
+}
+
 // FileSystem represents a wrapper around filesystem operations
 type FileSystem struct {
 	logger goai.Logger
@@ -25,6 +46,10 @@ type FileSystem struct {
 type FileSystemConfig struct {
 	AllowedDirectory string   // Base directory for all operations
 	BlockedPatterns  []string // Patterns to block (e.g., "*.exe", "*.dll")
+	AllowedPatterns  []string // Patterns files must match (e.g., "*.md", "*.txt"); empty allows all
+	// RequireDeleteConfirmation makes recursive deletes fail unless the confirm
+	// input repeats the target path or its hex-encoded SHA-256 hash
+	RequireDeleteConfirmation bool
 }
 
 // NewFileSystem creates a new instance of FileSystem
@@ -37,15 +62,19 @@ func NewFileSystem(logger goai.Logger, config FileSystemConfig) *FileSystem {
 
 // FileSystemAllInOneTool returns a Tool that performs filesystem operations
 func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(fs.logger, goai.Tool{
 		Name:        FileSystemToolName,
-		Description: "Performs filesystem operations like list, read, write, create, delete files and directories",
+		Description: newDescriptionBuilder("Performs filesystem operations like list, read, write, create, delete files and directories").
+			restrictedTo("All paths", fs.config.AllowedDirectory).
+			blocked("file patterns", fs.config.BlockedPatterns).
+			allowed("file patterns", fs.config.AllowedPatterns).
+			String(),
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["list", "tree", "read", "write", "create", "delete", "mkdir", "search"],
+					"enum": ["list", "tree", "read", "read_lines", "write", "create", "delete", "mkdir", "search", "exists", "touch"],
 					"description": "Filesystem operation to perform"
 				},
 				"path": {
@@ -68,6 +97,27 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 				"pattern": {
 					"type": "string",
 					"description": "File name pattern to match (e.g., *.txt)"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "For delete operations, list the paths that would be deleted without removing anything",
+					"default": false
+				},
+				"confirm": {
+					"type": "string",
+					"description": "For recursive delete operations, the target path repeated to confirm the delete when confirmation is required"
+				},
+				"time": {
+					"type": "string",
+					"description": "Access and modification time for touch operations in RFC3339 format (default: now)"
+				},
+				"start_line": {
+					"type": "integer",
+					"description": "First line to return for read_lines operations, 1-based (default: 1)"
+				},
+				"end_line": {
+					"type": "integer",
+					"description": "Last line to return for read_lines operations, inclusive (default: end of file)"
 				}
 			},
 			"required": ["operation", "path"]
@@ -92,6 +142,11 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 				Content   string `json:"content"`
 				Recursive bool   `json:"recursive"`
 				Pattern   string `json:"pattern"`
+				Time      string `json:"time"`
+				DryRun    bool   `json:"dry_run"`
+				StartLine int    `json:"start_line"`
+				EndLine   int    `json:"end_line"`
+				Confirm   string `json:"confirm"`
 			}
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -101,7 +156,7 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 				}).Error("Failed to unmarshal input parameters")
 
 				span.RecordError(err)
-				return returnErrorOutput(err), nil
+				return returnErrorOutput(newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))), nil
 			}
 
 			// Validate path is within allowed directory
@@ -117,7 +172,7 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 			}
 
 			if !fs.isPathAllowed(absPath) {
-				err = fmt.Errorf("path outside allowed directory: %s", input.Path)
+				err = permissionErrorf("path outside allowed directory: %s", input.Path)
 				fs.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
 					"path":                      input.Path,
@@ -144,18 +199,31 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 				result, opErr = fs.handleTree(absPath)
 			case "read":
 				result, opErr = fs.handleRead(absPath)
+			case "read_lines":
+				result, opErr = fs.handleReadLines(absPath, input.StartLine, input.EndLine)
 			case "write":
 				result, opErr = fs.handleWrite(absPath, input.Content)
 			case "create":
 				result, opErr = fs.handleCreate(absPath)
 			case "delete":
-				result, opErr = fs.handleDelete(absPath, input.Recursive)
+				if input.DryRun {
+					result, opErr = fs.handleDeleteDryRun(absPath, input.Recursive)
+				} else {
+					opErr = fs.checkDeleteConfirmation(input.Path, absPath, input.Recursive, input.Confirm)
+					if opErr == nil {
+						result, opErr = fs.handleDelete(absPath, input.Recursive)
+					}
+				}
 			case "mkdir":
 				result, opErr = fs.handleMkdir(absPath)
 			case "search":
-				result, opErr = fs.handleSearch(absPath, input.Pattern, input.Content, input.Recursive)
+				result, opErr = fs.handleSearch(ctx, absPath, input.Pattern, input.Content, input.Recursive)
+			case "exists":
+				result, opErr = fs.handleExists(absPath)
+			case "touch":
+				result, opErr = fs.handleTouch(absPath, input.Time)
 			default:
-				opErr = fmt.Errorf("unsupported operation: %s", input.Operation)
+				opErr = validationErrorf("unsupported operation: %s", input.Operation)
 			}
 
 			if opErr != nil {
@@ -167,7 +235,7 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 				}).Error("Operation failed")
 
 				span.RecordError(opErr)
-				return returnErrorOutput(opErr), nil
+				return returnErrorOutput(fileSystemError(opErr)), nil
 			}
 
 			fs.logger.WithFields(map[string]interface{}{
@@ -178,7 +246,19 @@ func (fs *FileSystem) FileSystemAllInOneTool() goai.Tool {
 
 			return result, nil
 		},
+	})
+}
+
+// fileSystemError classifies a failed operation by its cause: a missing path is
+// not_found and a denied access is permission
+func fileSystemError(err error) error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return newToolError(ErrorKindNotFound, err)
+	case errors.Is(err, os.ErrPermission):
+		return newToolError(ErrorKindPermission, err)
 	}
+	return err
 }
 
 func (fs *FileSystem) handleList(ctx context.Context, path string, recursive bool) (goai.CallToolResult, error) {
@@ -307,14 +387,97 @@ func (fs *FileSystem) handleRead(path string) (goai.CallToolResult, error) {
 		return goai.CallToolResult{}, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	mimeType := http.DetectContentType(content)
+	if strings.HasPrefix(mimeType, "text/") {
+		return goai.CallToolResult{
+			Content: []goai.ToolResultContent{{
+				Type: "text",
+				Text: string(content),
+			}},
+		}, nil
+	}
+
+	// Binary content would be corrupted as text, so return it base64 encoded
+	resultJSON, err := json.Marshal(binaryFileContent{
+		Encoding: "base64",
+		MimeType: mimeType,
+		Data:     base64.StdEncoding.EncodeToString(content),
+	})
+	if err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to marshal file content: %w", err)
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: string(resultJSON),
+		}},
+	}, nil
+}
+
+// handleReadLines returns the lines from startLine to endLine (1-based,
+// inclusive), each prefixed with its line number. The file is streamed, so
+// only the requested lines are held in memory. An endLine of zero or past the
+// end of the file reads to the end of the file.
+func (fs *FileSystem) handleReadLines(path string, startLine, endLine int) (goai.CallToolResult, error) {
+	if err := fs.validatePath(path); err != nil {
+		return goai.CallToolResult{}, err
+	}
+
+	if startLine == 0 {
+		startLine = 1
+	}
+	if startLine < 1 {
+		return goai.CallToolResult{}, validationErrorf("start_line must be at least 1, got %d", startLine)
+	}
+	if endLine != 0 && endLine < startLine {
+		return goai.CallToolResult{}, validationErrorf("end_line %d is before start_line %d", endLine, startLine)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), readLinesMaxLineLength)
+
+	var result strings.Builder
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if lineNum < startLine {
+			continue
+		}
+		if endLine != 0 && lineNum > endLine {
+			break
+		}
+		fmt.Fprintf(&result, "%d: %s\n", lineNum, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if lineNum < startLine {
+		return goai.CallToolResult{}, validationErrorf("start_line %d is beyond the end of the file (%d lines)", startLine, lineNum)
+	}
+
 	return goai.CallToolResult{
 		Content: []goai.ToolResultContent{{
 			Type: "text",
-			Text: string(content),
+			Text: result.String(),
 		}},
 	}, nil
 }
 
+// binaryFileContent is the read result for files that are not text
+type binaryFileContent struct {
+	Encoding string `json:"encoding"`
+	MimeType string `json:"mime"`
+	Data     string `json:"data"`
+}
+
 func (fs *FileSystem) handleWrite(path string, content string) (goai.CallToolResult, error) {
 	if err := fs.validatePath(path); err != nil {
 		return goai.CallToolResult{}, err
@@ -369,7 +532,7 @@ func (fs *FileSystem) handleDelete(path string, recursive bool) (goai.CallToolRe
 			return goai.CallToolResult{}, fmt.Errorf("validation failed for recursive delete: %w", err)
 		}
 		err = os.RemoveAll(path)
-	} else {
+	} else if err = checkRemovable(path); err == nil {
 		err = os.Remove(path)
 	}
 
@@ -385,11 +548,101 @@ func (fs *FileSystem) handleDelete(path string, recursive bool) (goai.CallToolRe
 	}, nil
 }
 
-func (fs *FileSystem) handleMkdir(path string) (goai.CallToolResult, error) {
+// checkDeleteConfirmation rejects a recursive delete whose confirm value matches
+// neither the requested path, its absolute form nor the SHA-256 of the absolute
+// path, when delete confirmation is required
+func (fs *FileSystem) checkDeleteConfirmation(path, absPath string, recursive bool, confirm string) error {
+	if !fs.config.RequireDeleteConfirmation || !recursive {
+		return nil
+	}
+
+	hash := sha256.Sum256([]byte(absPath))
+	switch confirm {
+	case "":
+		return validationErrorf("recursive delete of %s requires confirm to be set to the target path", path)
+	case path, absPath, hex.EncodeToString(hash[:]):
+		return nil
+	default:
+		return validationErrorf("confirm value does not match the target path %s", path)
+	}
+}
+
+// checkRemovable reports whether a non-recursive delete can remove path,
+// rejecting a directory that is not empty
+func checkRemovable(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || !info.IsDir() {
+		return err
+	}
+
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	if _, err := dir.Readdirnames(1); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return err
+		}
+		return validationErrorf("directory %s is not empty, set recursive to delete it", path)
+	}
+	return nil
+}
+
+// handleDeleteDryRun returns the paths a delete would remove without removing anything
+func (fs *FileSystem) handleDeleteDryRun(path string, recursive bool) (goai.CallToolResult, error) {
 	if err := fs.validatePath(path); err != nil {
 		return goai.CallToolResult{}, err
 	}
 
+	if !recursive {
+		if err := checkRemovable(path); err != nil {
+			return goai.CallToolResult{}, fmt.Errorf("failed to delete: %w", err)
+		}
+	} else if _, err := os.Lstat(path); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to delete: %w", err)
+	}
+
+	paths := []string{path}
+	if recursive {
+		paths = paths[:0]
+		err := filepath.Walk(path, func(subPath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := fs.validatePath(subPath); err != nil {
+				return err
+			}
+			paths = append(paths, subPath)
+			return nil
+		})
+		if err != nil {
+			return goai.CallToolResult{}, fmt.Errorf("validation failed for recursive delete: %w", err)
+		}
+	}
+
+	resultJSON, err := json.MarshalIndent(map[string]interface{}{
+		"dry_run":      true,
+		"would_delete": paths,
+	}, "", "  ")
+	if err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "text",
+			Text: string(resultJSON),
+		}},
+	}, nil
+}
+
+func (fs *FileSystem) handleMkdir(path string) (goai.CallToolResult, error) {
+	if err := fs.validateDirectoryPath(path); err != nil {
+		return goai.CallToolResult{}, err
+	}
+
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return goai.CallToolResult{}, fmt.Errorf("failed to create directory: %w", err)
 	}
@@ -402,13 +655,80 @@ func (fs *FileSystem) handleMkdir(path string) (goai.CallToolResult, error) {
 	}, nil
 }
 
-// isPathAllowed checks if the given path is within the allowed directory
-func (fs *FileSystem) isPathAllowed(path string) bool {
-	if fs.config.AllowedDirectory == "" {
-		return true
+func (fs *FileSystem) handleExists(path string) (goai.CallToolResult, error) {
+	if err := fs.validatePath(path); err != nil {
+		return goai.CallToolResult{}, err
 	}
 
-	allowedAbs, err := filepath.Abs(fs.config.AllowedDirectory)
+	type existsInfo struct {
+		Exists bool `json:"exists"`
+		IsDir  bool `json:"is_dir"`
+	}
+
+	var result existsInfo
+	info, err := os.Stat(path)
+	switch {
+	case err == nil:
+		result = existsInfo{Exists: true, IsDir: info.IsDir()}
+	case os.IsNotExist(err):
+		result = existsInfo{Exists: false}
+	default:
+		return goai.CallToolResult{}, fmt.Errorf("failed to stat path: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "text",
+			Text: string(resultJSON),
+		}},
+	}, nil
+}
+
+func (fs *FileSystem) handleTouch(path string, timestamp string) (goai.CallToolResult, error) {
+	if err := fs.validatePath(path); err != nil {
+		return goai.CallToolResult{}, err
+	}
+
+	t := time.Now()
+	if timestamp != "" {
+		parsed, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("invalid time %q, expected RFC3339: %w", timestamp, err))
+		}
+		t = parsed
+	}
+
+	// Directories cannot be opened for writing, so only their times are updated
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return goai.CallToolResult{}, fmt.Errorf("failed to touch file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return goai.CallToolResult{}, fmt.Errorf("failed to touch file: %w", err)
+		}
+	}
+
+	if err := os.Chtimes(path, t, t); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to update file times: %w", err)
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "text",
+			Text: fmt.Sprintf("Successfully touched %s at %s", path, t.Format(time.RFC3339)),
+		}},
+	}, nil
+}
+
+// isPathAllowed checks if the given path is within the allowed directory
+func (fs *FileSystem) isPathAllowed(path string) bool {
+	allowed, err := isPathWithinDirectory(path, fs.config.AllowedDirectory)
 	if err != nil {
 		fs.logger.WithFields(map[string]interface{}{
 			goai.ErrorLogField: err,
@@ -416,20 +736,59 @@ func (fs *FileSystem) isPathAllowed(path string) bool {
 		}).Error("Failed to resolve allowed directory path")
 		return false
 	}
+	return allowed
+}
+
+// isPathWithinDirectory reports whether path resolves inside allowedDir.
+// An empty allowedDir means no restriction.
+func isPathWithinDirectory(path, allowedDir string) (bool, error) {
+	if allowedDir == "" {
+		return true, nil
+	}
+
+	allowedAbs, err := filepath.Abs(allowedDir)
+	if err != nil {
+		return false, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, err
+	}
 
 	// Clean and standardize paths
-	path = filepath.Clean(path)
+	absPath = filepath.Clean(absPath)
 	allowedAbs = filepath.Clean(allowedAbs)
 
 	// Check if the path is within allowed directory
-	rel, err := filepath.Rel(allowedAbs, path)
+	rel, err := filepath.Rel(allowedAbs, absPath)
 	if err != nil {
-		return false
+		return false, nil
 	}
 
 	// Check if the path doesn't start with ".." which would indicate
 	// it's outside the allowed directory
-	return !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && rel != ".."
+	return !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && rel != "..", nil
+}
+
+// searchResultPath reports a search match relative to the allowed directory,
+// or as the absolute path when no allowed directory is set or it does not
+// contain the match
+func (fs *FileSystem) searchResultPath(path string) string {
+	if fs.config.AllowedDirectory == "" {
+		return path
+	}
+
+	rootAbs, err := filepath.Abs(fs.config.AllowedDirectory)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(rootAbs, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
 }
 
 // isPathBlocked checks if the path matches any blocked patterns
@@ -451,26 +810,74 @@ func (fs *FileSystem) isPathBlocked(path string) bool {
 	return false
 }
 
-// validatePath combines path validation checks
+// matchesAllowedPattern checks if the path matches one of the allowed patterns.
+// Every path matches when no allowed patterns are configured.
+func (fs *FileSystem) matchesAllowedPattern(path string) bool {
+	if len(fs.config.AllowedPatterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range fs.config.AllowedPatterns {
+		matched, err := filepath.Match(pattern, filepath.Base(path))
+		if err != nil {
+			fs.logger.WithFields(map[string]interface{}{
+				goai.ErrorLogField: err,
+				"pattern":          pattern,
+				"path":             path,
+			}).Error("Failed to match pattern")
+			continue
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePath combines path validation checks. Allowed patterns describe file
+// types, so existing directories don't have to match them.
 func (fs *FileSystem) validatePath(path string) error {
+	if err := fs.validateDirectoryPath(path); err != nil {
+		return err
+	}
+	if !fs.matchesAllowedPattern(path) {
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			return permissionErrorf("path does not match any allowed pattern: %s", path)
+		}
+	}
+	return nil
+}
+
+// validateDirectoryPath applies the path validation checks that hold for directories
+func (fs *FileSystem) validateDirectoryPath(path string) error {
 	if !fs.isPathAllowed(path) {
-		return fmt.Errorf("path is outside allowed directory: %s", path)
+		return permissionErrorf("path is outside allowed directory: %s", path)
 	}
 	if fs.isPathBlocked(path) {
-		return fmt.Errorf("path matches blocked pattern: %s", path)
+		return permissionErrorf("path matches blocked pattern: %s", path)
 	}
 	return nil
 }
 
-func (fs *FileSystem) handleSearch(root string, pattern string, searchContent string, recursive bool) (goai.CallToolResult, error) {
+// handleSearch walks the tree and returns the files matching the name pattern
+// and, when searchContent is set, containing it. File contents are read by a
+// bounded pool of workers; matches are reported in walk order. The walk stops
+// as soon as ctx is cancelled.
+func (fs *FileSystem) handleSearch(ctx context.Context, root string, pattern string, searchContent string, recursive bool) (goai.CallToolResult, error) {
 	if err := fs.validatePath(root); err != nil {
 		return goai.CallToolResult{}, err
 	}
 
-	var matches []string
+	// candidates holds pointers so workers can record results while the walk appends
+	var candidates []*searchCandidate
+	var readers errgroup.Group
+	readers.SetLimit(fileSystemSearchConcurrency)
 	searchContent = strings.TrimSpace(searchContent)
 
 	walkFn := func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
 			return err
 		}
@@ -484,54 +891,73 @@ func (fs *FileSystem) handleSearch(root string, pattern string, searchContent st
 			return nil
 		}
 
-		// Check if we should process this file
-		shouldProcess := true
-
 		// Check file pattern match if specified
 		if pattern != "" {
 			matched, err := filepath.Match(pattern, filepath.Base(path))
 			if err != nil {
 				return err
 			}
-			shouldProcess = shouldProcess && matched
+			if !matched {
+				return nil
+			}
 		}
 
-		// Check content if specified
-		if shouldProcess && searchContent != "" {
-			data, err := os.ReadFile(path)
-			if err != nil {
-				fs.logger.WithFields(map[string]interface{}{
-					goai.ErrorLogField: err,
-					"path":                      path,
-				}).Error("Failed to read file for content search")
-				return nil // Skip files we can't read
-			}
-			shouldProcess = strings.Contains(string(data), searchContent)
+		// Files the configuration doesn't allow, e.g. blocked ones or ones not
+		// matching the allowed patterns, are neither read nor reported
+		if err := fs.validatePath(path); err != nil {
+			return nil
 		}
 
-		// Add to matches if all conditions are met
-		if shouldProcess {
-			relPath, err := filepath.Rel(fs.config.AllowedDirectory, path)
-			if err != nil {
-				return err
-			}
-			matches = append(matches, relPath)
+		candidate := &searchCandidate{path: path, matched: searchContent == ""}
+		candidates = append(candidates, candidate)
+
+		// Check content if specified
+		if searchContent != "" {
+			readers.Go(func() error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				data, err := os.ReadFile(candidate.path)
+				if err != nil {
+					fs.logger.WithFields(map[string]interface{}{
+						goai.ErrorLogField: err,
+						"path":             candidate.path,
+					}).Error("Failed to read file for content search")
+					return nil // Skip files we can't read
+				}
+				candidate.matched = strings.Contains(string(data), searchContent)
+				return nil
+			})
 		}
 
 		return nil
 	}
 
 	err := filepath.Walk(root, walkFn)
+	// Always wait for the readers, so none outlives the search
+	if readErr := readers.Wait(); err == nil {
+		err = readErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
 	if err != nil {
 		fs.logger.WithFields(map[string]interface{}{
 			goai.ErrorLogField: err,
-			"root":                      root,
-			"pattern":                   pattern,
-			"content":                   searchContent,
+			"root":             root,
+			"pattern":          pattern,
+			"content":          searchContent,
 		}).Error("Failed to search files")
 		return goai.CallToolResult{}, err
 	}
 
+	var matches []string
+	for _, candidate := range candidates {
+		if candidate.matched {
+			matches = append(matches, fs.searchResultPath(candidate.path))
+		}
+	}
+
 	if len(matches) == 0 {
 		return goai.CallToolResult{
 			Content: []goai.ToolResultContent{
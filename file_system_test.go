@@ -1,11 +1,22 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"github.com/stretchr/testify/assert"
@@ -170,6 +181,59 @@ func TestFileSystem_Read(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+func TestFileSystem_ReadLines(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "source.txt")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\nfour\nfive\n"), 0644))
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: tempDir})
+
+	tests := []struct {
+		name      string
+		startLine int
+		endLine   int
+		want      string
+		wantErr   string
+	}{
+		{name: "mid-file range", startLine: 2, endLine: 4, want: "2: two\n3: three\n4: four\n"},
+		{name: "end past end of file", startLine: 4, endLine: 100, want: "4: four\n5: five\n"},
+		{name: "end omitted", startLine: 5, want: "5: five\n"},
+		{name: "start past end of file", startLine: 9, endLine: 12, wantErr: "start_line 9 is beyond the end of the file (5 lines)"},
+		{name: "end before start", startLine: 3, endLine: 2, wantErr: "end_line 2 is before start_line 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := json.Marshal(map[string]interface{}{
+				"operation":  "read_lines",
+				"path":       path,
+				"start_line": tt.startLine,
+				"end_line":   tt.endLine,
+			})
+			require.NoError(t, err)
+
+			result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: args,
+			})
+			require.NoError(t, err)
+
+			if tt.wantErr != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, result.Content[0].Text, tt.wantErr)
+				return
+			}
+			assert.False(t, result.IsError, result.Content[0].Text)
+			assert.Equal(t, tt.want, result.Content[0].Text)
+		})
+	}
+}
+
 func TestFileSystem_Write(t *testing.T) {
 	// Create mock logger with proper expectations
 	mockLogger := &MockLogger{}
@@ -244,6 +308,82 @@ func TestFileSystem_Write(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+func TestFileSystem_AllowedPatterns(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "docs"), 0755))
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{
+		AllowedDirectory: tempDir,
+		AllowedPatterns:  []string{"*.txt"},
+	})
+
+	tests := []struct {
+		name        string
+		input       map[string]interface{}
+		errContains string
+	}{
+		{
+			name: "write allowed pattern",
+			input: map[string]interface{}{
+				"operation": "write",
+				"path":      filepath.Join(tempDir, "docs", "notes.txt"),
+				"content":   "notes",
+			},
+		},
+		{
+			name: "write rejected pattern",
+			input: map[string]interface{}{
+				"operation": "write",
+				"path":      filepath.Join(tempDir, "main.go"),
+				"content":   "package main",
+			},
+			errContains: "path does not match any allowed pattern",
+		},
+		{
+			name: "list directory",
+			input: map[string]interface{}{
+				"operation": "list",
+				"path":      filepath.Join(tempDir, "docs"),
+			},
+		},
+		{
+			name: "mkdir",
+			input: map[string]interface{}{
+				"operation": "mkdir",
+				"path":      filepath.Join(tempDir, "reports"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+
+			result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: args,
+			})
+			require.NoError(t, err)
+
+			if tt.errContains != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, result.Content[0].Text, tt.errContains)
+				return
+			}
+			assert.False(t, result.IsError, result.Content[0].Text)
+		})
+	}
+
+	_, err := os.Stat(filepath.Join(tempDir, "main.go"))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestFileSystem_Delete(t *testing.T) {
 	// Create mock logger with proper expectations
 	mockLogger := &MockLogger{}
@@ -630,3 +770,538 @@ func TestFileSystem_Search(t *testing.T) {
 
 	mockLogger.AssertExpectations(t)
 }
+
+func TestFileSystem_SearchSkipsDisallowedFiles(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	tempDir := t.TempDir()
+	for name, content := range map[string]string{
+		"notes.txt":       "token=abc",
+		"config.env":      "token=abc",
+		"subdir/keys.txt": "token=abc",
+		"subdir/id.key":   "token=abc",
+	} {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{
+		AllowedDirectory: tempDir,
+		AllowedPatterns:  []string{"*.txt", "*.key"},
+		BlockedPatterns:  []string{"*.key"},
+	})
+
+	args, err := json.Marshal(map[string]interface{}{
+		"operation": "search",
+		"path":      tempDir,
+		"content":   "token",
+		"recursive": true,
+	})
+	require.NoError(t, err)
+
+	result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      FileSystemToolName,
+		Arguments: args,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "notes.txt\nsubdir/keys.txt", result.Content[0].Text)
+}
+
+func TestFileSystem_SearchResultPaths(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	tempDir := t.TempDir()
+	nestedFile := filepath.Join(tempDir, "subdir", "nested", "notes.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(nestedFile), 0755))
+	require.NoError(t, os.WriteFile(nestedFile, []byte("notes"), 0644))
+
+	tests := []struct {
+		name   string
+		config FileSystemConfig
+		want   string
+	}{
+		{
+			name:   "relative to the allowed directory",
+			config: FileSystemConfig{AllowedDirectory: tempDir},
+			want:   filepath.Join("subdir", "nested", "notes.txt"),
+		},
+		{
+			name:   "allowed directory with redundant elements",
+			config: FileSystemConfig{AllowedDirectory: filepath.Join(tempDir, "subdir") + "/.."},
+			want:   filepath.Join("subdir", "nested", "notes.txt"),
+		},
+		{
+			name:   "absolute without an allowed directory",
+			config: FileSystemConfig{},
+			want:   nestedFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := NewFileSystem(mockLogger, tt.config)
+
+			args, err := json.Marshal(map[string]interface{}{
+				"operation": "search",
+				"path":      filepath.Join(tempDir, "subdir"),
+				"pattern":   "*.txt",
+				"recursive": true,
+			})
+			require.NoError(t, err)
+
+			result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: args,
+			})
+			require.NoError(t, err)
+			require.False(t, result.IsError, result.Content[0].Text)
+			assert.Equal(t, tt.want, result.Content[0].Text)
+		})
+	}
+}
+
+func TestFileSystem_SearchContentOrdering(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	tempDir := t.TempDir()
+	var want []string
+	for i := 0; i < 60; i++ {
+		name := filepath.Join(fmt.Sprintf("dir%02d", i%6), fmt.Sprintf("file%02d.txt", i))
+		content := "nothing here"
+		if i%3 == 0 {
+			content = "needle in a haystack"
+		}
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, filepath.Dir(name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644))
+		if i%3 == 0 {
+			want = append(want, name)
+		}
+	}
+	// filepath.Walk visits entries in lexical order
+	sort.Strings(want)
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: tempDir})
+	for i := 0; i < 5; i++ {
+		result, err := fs.handleSearch(context.Background(), tempDir, "*.txt", "needle", true)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join(want, "\n"), result.Content[0].Text)
+	}
+}
+
+func TestFileSystem_SearchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first file of the walk cannot be read; the search is cancelled
+	// while that failure is logged, with the rest of the tree still unvisited.
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return().Maybe()
+	mockLogger.On("Error", []interface{}{"Failed to read file for content search"}).Run(func(mock.Arguments) { cancel() }).Return()
+	mockLogger.On("Error", []interface{}{"Failed to search files"}).Return()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.Symlink(filepath.Join(tempDir, "missing"), filepath.Join(tempDir, "a-broken.txt")))
+	for i := 0; i < 20; i++ {
+		dir := filepath.Join(tempDir, fmt.Sprintf("dir%02d", i))
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		for j := 0; j < 50; j++ {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%02d.txt", j)), []byte("needle"), 0644))
+		}
+	}
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: tempDir})
+
+	start := time.Now()
+	_, err := fs.handleSearch(ctx, tempDir, "*.txt", "needle", true)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestFileSystem_Exists(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.txt")
+	require.NoError(t, os.WriteFile(testFile, []byte("test content"), 0644))
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{
+		AllowedDirectory: tempDir,
+		BlockedPatterns:  []string{"*.exe", "*.dll"},
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		isError  bool
+		expected string
+	}{
+		{
+			name:     "existing file",
+			path:     testFile,
+			expected: `{"exists":true,"is_dir":false}`,
+		},
+		{
+			name:     "existing directory",
+			path:     tempDir,
+			expected: `{"exists":true,"is_dir":true}`,
+		},
+		{
+			name:     "missing path",
+			path:     filepath.Join(tempDir, "missing.txt"),
+			expected: `{"exists":false,"is_dir":false}`,
+		},
+		{
+			name:    "outside allowed directory",
+			path:    "/etc/passwd",
+			isError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := json.Marshal(map[string]interface{}{
+				"operation": "exists",
+				"path":      tt.path,
+			})
+			require.NoError(t, err)
+
+			result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: args,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.isError, result.IsError)
+			if !tt.isError {
+				assert.JSONEq(t, tt.expected, result.Content[0].Text)
+			}
+		})
+	}
+}
+
+func TestFileSystem_Touch(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+	tempDir := t.TempDir()
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{
+		AllowedDirectory: tempDir,
+		BlockedPatterns:  []string{"*.exe", "*.dll"},
+	})
+
+	touch := func(t *testing.T, input map[string]interface{}) goai.CallToolResult {
+		input["operation"] = "touch"
+		args, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+			Name:      FileSystemToolName,
+			Arguments: args,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("create new file", func(t *testing.T) {
+		path := filepath.Join(tempDir, "new.txt")
+
+		before := time.Now().Add(-time.Second)
+		result := touch(t, map[string]interface{}{"path": path})
+
+		require.False(t, result.IsError, result.Content[0].Text)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), info.Size())
+		assert.True(t, info.ModTime().After(before))
+	})
+
+	t.Run("update existing file", func(t *testing.T) {
+		path := filepath.Join(tempDir, "existing.txt")
+		require.NoError(t, os.WriteFile(path, []byte("keep me"), 0644))
+
+		result := touch(t, map[string]interface{}{"path": path, "time": "2024-01-02T03:04:05Z"})
+
+		require.False(t, result.IsError, result.Content[0].Text)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.True(t, info.ModTime().Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "keep me", string(content))
+	})
+
+	t.Run("update existing directory", func(t *testing.T) {
+		path := filepath.Join(tempDir, "dir")
+		require.NoError(t, os.Mkdir(path, 0755))
+
+		result := touch(t, map[string]interface{}{"path": path, "time": "2024-01-02T03:04:05Z"})
+
+		require.False(t, result.IsError, result.Content[0].Text)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+		assert.True(t, info.ModTime().Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	})
+
+	t.Run("blocked pattern", func(t *testing.T) {
+		path := filepath.Join(tempDir, "tool.exe")
+
+		result := touch(t, map[string]interface{}{"path": path})
+
+		assert.True(t, result.IsError)
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		result := touch(t, map[string]interface{}{"path": filepath.Join(tempDir, "x.txt"), "time": "yesterday"})
+
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].Text, "expected RFC3339")
+	})
+}
+
+func TestFileSystem_DeleteDryRun(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "subdir")
+	nested := filepath.Join(target, "nested")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "b.txt"), []byte("b"), 0644))
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: tempDir})
+
+	args, err := json.Marshal(map[string]interface{}{
+		"operation": "delete",
+		"path":      target,
+		"recursive": true,
+		"dry_run":   true,
+	})
+	require.NoError(t, err)
+
+	result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      FileSystemToolName,
+		Arguments: args,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var output struct {
+		DryRun      bool     `json:"dry_run"`
+		WouldDelete []string `json:"would_delete"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &output))
+	assert.True(t, output.DryRun)
+	assert.ElementsMatch(t, []string{
+		target,
+		filepath.Join(target, "a.txt"),
+		nested,
+		filepath.Join(nested, "b.txt"),
+	}, output.WouldDelete)
+
+	// Nothing was removed
+	for _, path := range output.WouldDelete {
+		_, err := os.Stat(path)
+		assert.NoError(t, err, path)
+	}
+}
+
+func TestFileSystem_DeleteNonEmptyDirectoryWithoutRecursive(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "subdir")
+	require.NoError(t, os.MkdirAll(target, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "a.txt"), []byte("a"), 0644))
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: tempDir})
+
+	for _, dryRun := range []bool{true, false} {
+		t.Run(fmt.Sprintf("dry_run=%t", dryRun), func(t *testing.T) {
+			args, err := json.Marshal(map[string]interface{}{
+				"operation": "delete",
+				"path":      target,
+				"dry_run":   dryRun,
+			})
+			require.NoError(t, err)
+
+			result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: args,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, "is not empty, set recursive to delete it")
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+			assert.DirExists(t, target)
+		})
+	}
+}
+
+func TestFileSystem_DeleteConfirmation(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+	tempDir := t.TempDir()
+	fs := NewFileSystem(mockLogger, FileSystemConfig{
+		AllowedDirectory:          tempDir,
+		RequireDeleteConfirmation: true,
+	})
+
+	hashOf := func(path string) string {
+		sum := sha256.Sum256([]byte(path))
+		return hex.EncodeToString(sum[:])
+	}
+
+	tests := []struct {
+		name          string
+		confirm       func(target string) string
+		recursive     bool
+		expectedError string
+	}{
+		{
+			name:          "missing confirm",
+			confirm:       func(target string) string { return "" },
+			recursive:     true,
+			expectedError: "requires confirm to be set to the target path",
+		},
+		{
+			name:          "mismatched confirm",
+			confirm:       func(target string) string { return tempDir },
+			recursive:     true,
+			expectedError: "confirm value does not match the target path",
+		},
+		{
+			name:      "matching path",
+			confirm:   func(target string) string { return target },
+			recursive: true,
+		},
+		{
+			name:      "matching hash",
+			confirm:   hashOf,
+			recursive: true,
+		},
+		{
+			name:      "non-recursive delete needs no confirm",
+			confirm:   func(target string) string { return "" },
+			recursive: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := filepath.Join(tempDir, strings.ReplaceAll(tt.name, " ", "_"))
+			if tt.recursive {
+				require.NoError(t, os.MkdirAll(filepath.Join(target, "nested"), 0755))
+			} else {
+				require.NoError(t, os.WriteFile(target, []byte("content"), 0644))
+			}
+
+			args, err := json.Marshal(map[string]interface{}{
+				"operation": "delete",
+				"path":      target,
+				"recursive": tt.recursive,
+				"confirm":   tt.confirm(target),
+			})
+			require.NoError(t, err)
+
+			result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      FileSystemToolName,
+				Arguments: args,
+			})
+			require.NoError(t, err)
+
+			if tt.expectedError != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, result.Content[0].Text, tt.expectedError)
+				assert.DirExists(t, target)
+				return
+			}
+			require.False(t, result.IsError, result.Content[0].Text)
+			assert.NoFileExists(t, target)
+			assert.NoDirExists(t, target)
+		})
+	}
+}
+
+func TestFileSystem_ReadBinary(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	tempDir := t.TempDir()
+	pngPath := filepath.Join(tempDir, "pixel.png")
+	require.NoError(t, os.WriteFile(pngPath, buf.Bytes(), 0644))
+	textPath := filepath.Join(tempDir, "notes.txt")
+	require.NoError(t, os.WriteFile(textPath, []byte("plain text"), 0644))
+
+	fs := NewFileSystem(mockLogger, FileSystemConfig{AllowedDirectory: tempDir})
+
+	read := func(path string) goai.CallToolResult {
+		args, err := json.Marshal(map[string]interface{}{
+			"operation": "read",
+			"path":      path,
+		})
+		require.NoError(t, err)
+
+		result, err := fs.FileSystemAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+			Name:      FileSystemToolName,
+			Arguments: args,
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content[0].Text)
+		return result
+	}
+
+	result := read(pngPath)
+	assert.Equal(t, "json", result.Content[0].Type)
+
+	var content binaryFileContent
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &content))
+	assert.Equal(t, "base64", content.Encoding)
+	assert.Equal(t, "image/png", content.MimeType)
+
+	decoded, err := base64.StdEncoding.DecodeString(content.Data)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), decoded)
+
+	result = read(textPath)
+	assert.Equal(t, "text", result.Content[0].Type)
+	assert.Equal(t, "plain text", result.Content[0].Text)
+}
@@ -4,24 +4,72 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// GetWeather is a tool that provides the current weather for a specified location.
-// The tool expects an input schema that includes a "location" field, which
-// specifies the city and state (e.g., "San Francisco, CA"). It returns the
-// weather information as text content.
-var GetWeather = goai.Tool{
+const (
+	weatherUnitsMetric   = "metric"
+	weatherUnitsImperial = "imperial"
+
+	defaultForecastDays = 3
+	maxForecastDays     = 7
+)
+
+// WeatherReport is the structured result of the current weather operation
+type WeatherReport struct {
+	Location    string  `json:"location"`
+	Units       string  `json:"units"`
+	Temperature float64 `json:"temperature"`
+	Condition   string  `json:"condition"`
+}
+
+// WeatherForecastDay is a single day of a weather forecast
+type WeatherForecastDay struct {
+	Date      string  `json:"date"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Condition string  `json:"condition"`
+}
+
+// WeatherForecast is the structured result of the forecast operation
+type WeatherForecast struct {
+	Location string               `json:"location"`
+	Units    string               `json:"units"`
+	Days     []WeatherForecastDay `json:"days"`
+}
+
+// GetWeather is a tool that provides the current weather or a multi-day forecast
+// for a specified location. The tool expects an input schema that includes a
+// "location" field, which specifies the city and state (e.g., "San Francisco, CA").
+// It returns the weather information as JSON with numeric temperatures in the
+// requested units.
+var GetWeather = withInputValidation(nil, goai.Tool{
 	Name:        "get_weather",
-	Description: "Get the current weather for a given location.",
+	Description: "Get the current weather or a daily forecast for a given location.",
 	InputSchema: json.RawMessage(`{
 				"type": "object",
 				"properties": {
 					"location": {
 						"type": "string",
 						"description": "The city and state, e.g. San Francisco, CA"
+					},
+					"operation": {
+						"type": "string",
+						"enum": ["current", "forecast"],
+						"description": "Weather operation to perform (default: current)"
+					},
+					"units": {
+						"type": "string",
+						"enum": ["metric", "imperial"],
+						"description": "Temperature units: metric for Celsius, imperial for Fahrenheit (default: imperial)"
+					},
+					"days": {
+						"type": "integer",
+						"description": "Number of forecast days to return (default: 3, max: 7)"
 					}
 				},
 				"required": ["location"]
@@ -42,10 +90,49 @@ var GetWeather = goai.Tool{
 		}()
 
 		var input struct {
-			Location string `json:"location"`
+			Location  string `json:"location"`
+			Operation string `json:"operation"`
+			Units     string `json:"units"`
+			Days      int    `json:"days"`
 		}
 		if err := json.Unmarshal(params.Arguments, &input); err != nil {
-			return goai.CallToolResult{}, err
+			return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+		}
+
+		if input.Units == "" {
+			input.Units = weatherUnitsImperial
+		}
+		if input.Units != weatherUnitsMetric && input.Units != weatherUnitsImperial {
+			err = validationErrorf("invalid units: %s (allowed: metric, imperial)", input.Units)
+			return returnErrorOutput(err), nil
+		}
+
+		var result interface{}
+		switch input.Operation {
+		case "", "current":
+			result = WeatherReport{
+				Location:    input.Location,
+				Units:       input.Units,
+				Temperature: convertTemperature(72, input.Units),
+				Condition:   "Sunny",
+			}
+		case "forecast":
+			if input.Days == 0 {
+				input.Days = defaultForecastDays
+			}
+			if input.Days < 1 || input.Days > maxForecastDays {
+				err = validationErrorf("days must be between 1 and %d, got %d", maxForecastDays, input.Days)
+				return returnErrorOutput(err), nil
+			}
+			result = buildForecast(input.Location, input.Units, input.Days, time.Now().UTC())
+		default:
+			err = validationErrorf("unsupported operation: %s", input.Operation)
+			return returnErrorOutput(err), nil
+		}
+
+		output, err := json.Marshal(result)
+		if err != nil {
+			return returnErrorOutput(newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal weather: %w", err))), nil
 		}
 
 		// Return result
@@ -53,9 +140,37 @@ var GetWeather = goai.Tool{
 			Content: []goai.ToolResultContent{
 				{
 					Type: "text",
-					Text: fmt.Sprintf("Weather in %s: Sunny, 72°F", input.Location),
+					Text: string(output),
 				},
 			},
 		}, nil
 	},
+})
+
+// buildForecast returns a forecast for the given number of days starting the day after start
+func buildForecast(location, units string, days int, start time.Time) WeatherForecast {
+	conditions := []string{"Sunny", "Partly Cloudy", "Cloudy", "Light Rain"}
+
+	forecast := WeatherForecast{
+		Location: location,
+		Units:    units,
+		Days:     make([]WeatherForecastDay, 0, days),
+	}
+	for i := 0; i < days; i++ {
+		forecast.Days = append(forecast.Days, WeatherForecastDay{
+			Date:      start.AddDate(0, 0, i+1).Format(time.DateOnly),
+			High:      convertTemperature(float64(72-i), units),
+			Low:       convertTemperature(float64(58-i), units),
+			Condition: conditions[i%len(conditions)],
+		})
+	}
+	return forecast
+}
+
+// convertTemperature converts a Fahrenheit temperature to the requested units, rounded to one decimal
+func convertTemperature(fahrenheit float64, units string) float64 {
+	if units == weatherUnitsMetric {
+		return math.Round((fahrenheit-32)*5/9*10) / 10
+	}
+	return fahrenheit
 }
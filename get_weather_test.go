@@ -4,15 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 )
 
-func TestGetWeather(t *testing.T) {
-	// Define the input parameters
-	input := map[string]interface{}{
-		"location": "San Francisco, CA",
-	}
+func callGetWeather(t *testing.T, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+
 	inputBytes, err := json.Marshal(input)
 	if err != nil {
 		t.Fatalf("Failed to marshal input: %v", err)
@@ -29,10 +28,110 @@ func TestGetWeather(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Handler returned an error: %v", err)
 	}
+	return result
+}
+
+func TestGetWeather(t *testing.T) {
+	result := callGetWeather(t, map[string]interface{}{
+		"location": "San Francisco, CA",
+	})
 
 	// Check the result
-	expectedText := "Weather in San Francisco, CA: Sunny, 72°F"
-	if len(result.Content) != 1 || result.Content[0].Text != expectedText {
-		t.Errorf("Unexpected result: got %v, want %v", result.Content, expectedText)
+	var report WeatherReport
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &report); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+
+	expected := WeatherReport{Location: "San Francisco, CA", Units: "imperial", Temperature: 72, Condition: "Sunny"}
+	if report != expected {
+		t.Errorf("Unexpected result: got %+v, want %+v", report, expected)
+	}
+}
+
+func TestGetWeather_Units(t *testing.T) {
+	tests := []struct {
+		units    string
+		expected float64
+	}{
+		{units: "imperial", expected: 72},
+		{units: "metric", expected: 22.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.units, func(t *testing.T) {
+			result := callGetWeather(t, map[string]interface{}{
+				"location": "San Francisco, CA",
+				"units":    tt.units,
+			})
+
+			var report WeatherReport
+			if err := json.Unmarshal([]byte(result.Content[0].Text), &report); err != nil {
+				t.Fatalf("Failed to unmarshal result: %v", err)
+			}
+			if report.Units != tt.units || report.Temperature != tt.expected {
+				t.Errorf("Unexpected temperature: got %v %s, want %v %s", report.Temperature, report.Units, tt.expected, tt.units)
+			}
+		})
+	}
+}
+
+func TestGetWeather_InvalidUnits(t *testing.T) {
+	result := callGetWeather(t, map[string]interface{}{
+		"location": "San Francisco, CA",
+		"units":    "kelvin",
+	})
+
+	if !result.IsError {
+		t.Errorf("Expected an error result for invalid units, got %v", result.Content)
+	}
+}
+
+func TestGetWeather_ForecastDaysOutOfRange(t *testing.T) {
+	result := callGetWeather(t, map[string]interface{}{
+		"location":  "San Francisco, CA",
+		"operation": "forecast",
+		"days":      30,
+	})
+
+	if !result.IsError {
+		t.Fatalf("Expected an error result for 30 forecast days, got %v", result.Content)
+	}
+	if kind, ok := ResultErrorKind(result); !ok || kind != ErrorKindValidation {
+		t.Errorf("Expected error kind %q, got %q", ErrorKindValidation, kind)
+	}
+}
+
+func TestGetWeather_Forecast(t *testing.T) {
+	result := callGetWeather(t, map[string]interface{}{
+		"location":  "San Francisco, CA",
+		"operation": "forecast",
+		"units":     "metric",
+		"days":      3,
+	})
+
+	var forecast WeatherForecast
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &forecast); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+
+	if forecast.Location != "San Francisco, CA" || forecast.Units != "metric" {
+		t.Errorf("Unexpected forecast header: %+v", forecast)
+	}
+	if len(forecast.Days) != 3 {
+		t.Fatalf("Expected 3 forecast days, got %d", len(forecast.Days))
+	}
+	for _, day := range forecast.Days {
+		if _, err := time.Parse(time.DateOnly, day.Date); err != nil {
+			t.Errorf("Invalid forecast date %q: %v", day.Date, err)
+		}
+		if day.High < day.Low {
+			t.Errorf("Forecast high %v is below low %v", day.High, day.Low)
+		}
+		if day.Condition == "" {
+			t.Errorf("Forecast day %s has no condition", day.Date)
+		}
+	}
+	if forecast.Days[0].High != 22.2 {
+		t.Errorf("Expected first day high of 22.2°C, got %v", forecast.Days[0].High)
 	}
 }
@@ -3,8 +3,14 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"go.opentelemetry.io/otel/attribute"
@@ -12,6 +18,11 @@ import (
 
 const GitToolName = "git"
 
+// gitCommandPattern matches git subcommand names. Anything else, in particular
+// a global option such as -c or -C, would let the arguments run another
+// subcommand past the blocked commands and the repository path restriction.
+var gitCommandPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
 // Git represents a wrapper around the system's git command-line tool,
 // providing a programmatic interface for executing git commands.
 type Git struct {
@@ -21,10 +32,22 @@ type Git struct {
 
 // GitConfig holds the configuration for the Git tool
 type GitConfig struct {
-	// Add any configuration options here
-	// For example, you might want to add:
+	// DefaultRepoPath is used when repo_path is omitted. When set, repo_path must
+	// also resolve within it; relative repo paths are resolved against it.
 	DefaultRepoPath string
+	// BlockedCommands lists git commands that may not be executed. Entries are
+	// matched case-insensitively against the command (e.g. "push") or the command
+	// with arguments it is given in any position (e.g. "reset --hard"). Aliases
+	// cannot be defined through the tool, so they cannot hide a blocked command.
 	BlockedCommands []string
+	// Timeout bounds the duration of a single git command, so operations such as
+	// cloning a huge repository cannot hang. Zero means no timeout.
+	Timeout time.Duration
+	// AllowedRemoteHosts restricts the hosts that clone, fetch, pull, push,
+	// ls-remote and remote add/set-url may reach, e.g. "github.com", as well as
+	// the URLs written through config remote.<name>.url and url.<base>.insteadOf.
+	// Hosts are matched case-insensitively. Empty means any host is allowed.
+	AllowedRemoteHosts []string
 }
 
 // NewGit creates and returns a new instance of the Git wrapper with the provided configuration.
@@ -37,9 +60,13 @@ func NewGit(logger goai.Logger, config GitConfig) *Git {
 
 // GitAllInOneTool returns a goai.Tool that can perform various Git operations
 func (g *Git) GitAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GitToolName,
-		Description: "Performs any Git operation based on the provided command",
+		Description: newDescriptionBuilder("Performs any Git operation based on the provided command").
+			restrictedTo("Repository paths", g.config.DefaultRepoPath).
+			blocked("git commands", g.config.BlockedCommands).
+			allowed("remote hosts", g.config.AllowedRemoteHosts).
+			String(),
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
@@ -49,7 +76,7 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				},
 				"repo_path": {
 					"type": "string",
-					"description": "Path to Git repository"
+					"description": "Path to Git repository (defaults to the configured repository path)"
 				},
 				"args": {
 					"type": "array",
@@ -57,9 +84,13 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 						"type": "string"
 					},
 					"description": "Arguments for the Git command"
+				},
+				"json": {
+					"type": "boolean",
+					"description": "Return structured JSON for commands that support it (status, log)"
 				}
 			},
-			"required": ["command", "repo_path"]
+			"required": ["command"]
 		}`),
 		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
 			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
@@ -78,6 +109,7 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				Command  string   `json:"command"`
 				RepoPath string   `json:"repo_path"`
 				Args     []string `json:"args"`
+				JSON     bool     `json:"json"`
 			}
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -88,10 +120,76 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				}).Error("Failed to unmarshal input parameters")
 
 				span.RecordError(err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+			}
+
+			if isCommandBlocked(g.config.BlockedCommands, input.Command, input.Args) {
+				err := permissionErrorf("git command '%s' is blocked", input.Command)
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"tool":             GitToolName,
+					"command":          input.Command,
+				}).Error("Blocked git command")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
 			}
 
-			args := append([]string{"-C", input.RepoPath, input.Command}, input.Args...)
+			if !gitCommandPattern.MatchString(input.Command) {
+				err := validationErrorf("invalid git command '%s': must be a subcommand name such as status or log", input.Command)
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"tool":             GitToolName,
+					"command":          input.Command,
+				}).Error("Invalid git command")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"tool":             GitToolName,
+					"repo_path":        input.RepoPath,
+				}).Error("Invalid repository path")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if err := g.validateRemoteAccess(input.Command, input.Args, input.RepoPath); err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"tool":             GitToolName,
+					"command":          input.Command,
+					"args":             input.Args,
+				}).Error("Git remote or destination not allowed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			args := []string{"-C", input.RepoPath, input.Command}
+			parser, jsonOutput := gitJSONParsers[strings.ToLower(input.Command)]
+			jsonOutput = jsonOutput && input.JSON
+			if jsonOutput {
+				if err := parser.validateArgs(input.Command, input.Args); err != nil {
+					g.logger.WithFields(map[string]interface{}{
+						goai.ErrorLogField: err,
+						"tool":             GitToolName,
+						"command":          input.Command,
+						"args":             input.Args,
+					}).Error("Git arguments conflict with JSON output")
+
+					span.RecordError(err)
+					return returnErrorOutput(err), nil
+				}
+				args = append(args, parser.args...)
+			}
+			args = append(args, input.Args...)
 
 			g.logger.WithFields(map[string]interface{}{
 				"command":   input.Command,
@@ -99,6 +197,12 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				"args":      args,
 			}).Debug("Executing git command")
 
+			if g.config.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, g.config.Timeout)
+				defer cancel()
+			}
+
 			cmd := exec.CommandContext(ctx, "git", args...)
 
 			g.logger.WithFields(map[string]interface{}{
@@ -107,8 +211,17 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				"args":      args,
 			}).Debug("Executing git command")
 
-			output, err := cmd.CombinedOutput()
+			var output []byte
+			if jsonOutput {
+				// Keep warnings on stderr out of the output that gets parsed
+				output, err = cmd.Output()
+			} else {
+				output, err = cmd.CombinedOutput()
+			}
 			if err != nil {
+				if g.config.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					err = upstreamErrorf("git command timed out after %s", g.config.Timeout)
+				}
 				g.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
 					"output":                    string(output),
@@ -116,7 +229,7 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				}).Error("Git command failed")
 
 				span.RecordError(err)
-				return returnErrorOutput(err), nil
+				return returnErrorOutput(newToolError(ErrorKindUpstream, err)), nil
 			}
 
 			g.logger.WithFields(map[string]interface{}{
@@ -125,6 +238,15 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				"output":  string(output),
 			}).Debug("Git command completed successfully")
 
+			if jsonOutput {
+				parsed, err := json.Marshal(parser.parse(string(output)))
+				if err != nil {
+					span.RecordError(err)
+					return returnErrorOutput(newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal git output: %w", err))), nil
+				}
+				output = parsed
+			}
+
 			return goai.CallToolResult{
 				Content: []goai.ToolResultContent{{
 					Type: "text",
@@ -132,5 +254,457 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				}},
 			}, nil
 		},
+	})
+}
+
+// resolveRepoPath falls back to the default repository path when repoPath is
+// empty and restricts the result to the default repository path when configured
+func (g *Git) resolveRepoPath(repoPath string) (string, error) {
+	defaultPath := g.config.DefaultRepoPath
+	if repoPath == "" {
+		if defaultPath == "" {
+			return "", validationErrorf("repo_path is required when no default repository path is configured")
+		}
+		return defaultPath, nil
+	}
+
+	if defaultPath == "" {
+		return repoPath, nil
+	}
+
+	if !filepath.IsAbs(repoPath) {
+		repoPath = filepath.Join(defaultPath, repoPath)
+	}
+
+	allowed, err := isPathWithinDirectory(repoPath, defaultPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve repo_path %s: %w", repoPath, err)
+	}
+	if !allowed {
+		return "", permissionErrorf("repo_path %s is outside the default repository path %s", repoPath, defaultPath)
+	}
+	return repoPath, nil
+}
+
+// gitValueFlags lists, per command, the flags that take a separate value, so
+// the value is not mistaken for a repository URL or destination path
+var gitValueFlags = map[string]map[string]bool{
+	"clone": flagSet("-b", "--branch", "-o", "--origin", "-u", "--upload-pack", "--depth", "--reference",
+		"--reference-if-able", "--separate-git-dir", "--template", "-c", "--config", "-j", "--jobs", "--filter",
+		"--shallow-since", "--shallow-exclude", "--server-option", "--bundle-uri"),
+	"fetch": flagSet("--depth", "--deepen", "--shallow-since", "--shallow-exclude", "-j", "--jobs", "--upload-pack",
+		"-o", "--server-option", "--negotiation-tip", "--refmap", "--filter"),
+	"pull": flagSet("--depth", "--deepen", "--shallow-since", "--shallow-exclude", "-j", "--jobs", "--upload-pack",
+		"-o", "--server-option", "--negotiation-tip", "-s", "--strategy", "-X", "--strategy-option", "--cleanup"),
+	"push":      flagSet("-o", "--push-option", "--receive-pack", "--exec", "--repo"),
+	"ls-remote": flagSet("--upload-pack", "--exec", "-o", "--server-option", "--sort"),
+	"remote":    flagSet("-t", "-m"),
+	"config":    flagSet("-f", "--file", "--blob", "--type", "--default", "--comment", "--value"),
+	"worktree":  flagSet("-b", "-B", "--reason"),
+}
+
+func flagSet(flags ...string) map[string]bool {
+	set := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		set[flag] = true
+	}
+	return set
+}
+
+// parseGitArgs splits args into positional arguments and the values of flags
+// listed in valueFlags. Arguments after "--" are always positional.
+func parseGitArgs(args []string, valueFlags map[string]bool) ([]string, map[string]string) {
+	var positional []string
+	values := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		if name, value, found := strings.Cut(arg, "="); found {
+			values[name] = value
+			continue
+		}
+		if valueFlags[arg] && i+1 < len(args) {
+			values[arg] = args[i+1]
+			i++
+		}
+	}
+	return positional, values
+}
+
+// validateRemoteAccess checks the repository URLs of network commands, and the
+// URLs written to the repository configuration for later fetches and pushes,
+// against the allowed remote hosts. It also confines clone and worktree
+// destinations and the files written by git config to the default repository
+// path and rejects alias definitions.
+func (g *Git) validateRemoteAccess(command string, args []string, repoPath string) error {
+	// A global option in place of the subcommand would hide the real one
+	if !gitCommandPattern.MatchString(command) {
+		return validationErrorf("invalid git command '%s': must be a subcommand name such as status or log", command)
+	}
+	positional, values := parseGitArgs(args, gitValueFlags[command])
+
+	var urls, destinations []string
+	switch command {
+	case "clone":
+		if len(positional) > 0 {
+			urls = append(urls, positional[0])
+		}
+		if len(positional) > 1 {
+			destinations = append(destinations, positional[1])
+		}
+		if dir, ok := values["--separate-git-dir"]; ok {
+			destinations = append(destinations, dir)
+		}
+	case "fetch", "pull", "ls-remote":
+		if len(positional) > 0 {
+			urls = append(urls, positional[0])
+		}
+	case "push":
+		if len(positional) > 0 {
+			urls = append(urls, positional[0])
+		}
+		if repo, ok := values["--repo"]; ok {
+			urls = append(urls, repo)
+		}
+	case "remote":
+		if len(positional) > 2 && (positional[0] == "add" || positional[0] == "set-url") {
+			urls = append(urls, positional[2:]...)
+		}
+	case "config":
+		if err := validateGitConfigAlias(args, positional); err != nil {
+			return err
+		}
+		destinations = append(destinations, gitConfigFiles(args)...)
+		if g.config.DefaultRepoPath != "" {
+			for _, arg := range args {
+				if arg == "--global" || arg == "--system" {
+					return permissionErrorf("git config %s is outside the default repository path %s", arg, g.config.DefaultRepoPath)
+				}
+			}
+		}
+		if len(positional) > 0 && positional[0] == "set" {
+			positional = positional[1:]
+		}
+		if len(positional) > 1 {
+			if rawURL, ok := gitConfigRemoteURL(positional[0], positional[1]); ok {
+				urls = append(urls, rawURL)
+			}
+		}
+	case "worktree":
+		if len(positional) > 1 && positional[0] == "add" {
+			destinations = append(destinations, positional[1])
+		}
+		if len(positional) > 2 && positional[0] == "move" {
+			destinations = append(destinations, positional[2])
+		}
+	}
+
+	if len(g.config.AllowedRemoteHosts) > 0 {
+		for _, rawURL := range urls {
+			host, isRemote := remoteURLHost(rawURL)
+			if isRemote && !g.isRemoteHostAllowed(host) {
+				return permissionErrorf("remote %s is not allowed, allowed hosts: %s", rawURL, strings.Join(g.config.AllowedRemoteHosts, ", "))
+			}
+		}
+	}
+
+	if g.config.DefaultRepoPath != "" {
+		for _, dest := range destinations {
+			if !filepath.IsAbs(dest) {
+				dest = filepath.Join(repoPath, dest)
+			}
+			allowed, err := isPathWithinDirectory(dest, g.config.DefaultRepoPath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve destination %s: %w", dest, err)
+			}
+			if !allowed {
+				return permissionErrorf("destination %s is outside the default repository path %s", dest, g.config.DefaultRepoPath)
+			}
+		}
+	}
+	return nil
+}
+
+// gitConfigReadFlags are the git config flags that only read the configuration
+var gitConfigReadFlags = flagSet("--get", "--get-all", "--get-regexp", "--get-urlmatch", "-l", "--list")
+
+// validateGitConfigAlias rejects git config calls that write an alias, since
+// calling the alias would run the aliased command past the blocked commands.
+// Writes are calls with a key and a value, or a section rename, so reads such
+// as "config --get alias.st" stay allowed.
+func validateGitConfigAlias(args, positional []string) error {
+	for _, arg := range args {
+		if gitConfigReadFlags[arg] {
+			return nil
+		}
+	}
+	if len(positional) > 0 && (positional[0] == "get" || positional[0] == "list") {
+		return nil
+	}
+	if len(positional) > 0 && (positional[0] == "set" || positional[0] == "rename-section") {
+		positional = positional[1:]
+	}
+	if len(positional) < 2 {
+		return nil
+	}
+
+	for _, name := range positional[:2] {
+		lower := strings.ToLower(name)
+		if lower == "alias" || strings.HasPrefix(lower, "alias.") {
+			return permissionErrorf("git aliases cannot be defined: %s", name)
+		}
+	}
+	return nil
+}
+
+// gitConfigFiles returns the files named by the -f and --file options of a git
+// config call, including the attached (-fpath) and abbreviated (--fil) forms
+func gitConfigFiles(args []string) []string {
+	var files []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+
+		var value string
+		var attached bool
+		if name, v, found := strings.Cut(arg, "="); strings.HasPrefix(arg, "--") {
+			if name != "--file" && name != "--fil" {
+				continue
+			}
+			value, attached = v, found
+		} else if strings.HasPrefix(arg, "-") {
+			// Short flags can be grouped, as in -lf path
+			f := strings.IndexByte(arg, 'f')
+			if f < 1 {
+				continue
+			}
+			value, attached = arg[f+1:], f+1 < len(arg)
+		} else {
+			continue
+		}
+
+		if !attached {
+			if i+1 >= len(args) {
+				break
+			}
+			i++
+			value = args[i]
+		}
+		files = append(files, value)
+	}
+	return files
+}
+
+// gitConfigRemoteURL returns the repository URL a git config entry directs
+// network commands to: the value of remote.<name>.url and remote.<name>.pushurl,
+// or the base URL of url.<base>.insteadOf and url.<base>.pushInsteadOf
+func gitConfigRemoteURL(key, value string) (string, bool) {
+	lower := strings.ToLower(key)
+	if strings.HasPrefix(lower, "remote.") && (strings.HasSuffix(lower, ".url") || strings.HasSuffix(lower, ".pushurl")) {
+		return value, true
+	}
+	if strings.HasPrefix(lower, "url.") {
+		for _, suffix := range []string{".insteadof", ".pushinsteadof"} {
+			if strings.HasSuffix(lower, suffix) && len(key) > len("url.")+len(suffix) {
+				return key[len("url.") : len(key)-len(suffix)], true
+			}
+		}
+	}
+	return "", false
+}
+
+// remoteURLHost extracts the host of a git repository URL, either in URL form
+// (https://host/repo.git) or scp-like form (user@host:repo.git). It reports
+// false for local paths and remote names. Transports without a host, such as
+// file:// or ext::, yield an empty host.
+func remoteURLHost(rawURL string) (string, bool) {
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return "", true
+		}
+		return u.Hostname(), true
+	}
+	if strings.Contains(rawURL, "::") {
+		return "", true
+	}
+
+	colon := strings.Index(rawURL, ":")
+	if colon <= 0 || strings.Contains(rawURL[:colon], "/") {
+		return "", false
+	}
+	host := rawURL[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	return strings.Trim(host, "[]"), true
+}
+
+func (g *Git) isRemoteHostAllowed(host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, allowed := range g.config.AllowedRemoteHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitJSONParser holds the machine-readable flags for a git command, the user
+// flags that would change that output format and the function that converts
+// the resulting output into a JSON-serializable value
+type gitJSONParser struct {
+	args        []string
+	formatFlags []string
+	parse       func(output string) interface{}
+}
+
+// gitJSONParsers lists the git commands that support structured JSON output
+var gitJSONParsers = map[string]gitJSONParser{
+	"status": {
+		args:        []string{"--porcelain=v2", "-z"},
+		formatFlags: []string{"--porcelain", "--short", "-s", "--long"},
+		parse:       func(output string) interface{} { return parseGitStatus(output) },
+	},
+	"log": {
+		args:        []string{"--pretty=format:%H%x1f%an%x1f%aI%x1f%s%x1e"},
+		formatFlags: []string{"--format", "--pretty", "--oneline"},
+		parse:       func(output string) interface{} { return parseGitLog(output) },
+	},
+}
+
+// validateArgs rejects arguments that would override the output format the
+// parser expects, as a later --format or --pretty wins over the parser's own
+func (p gitJSONParser) validateArgs(command string, args []string) error {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		for _, flag := range p.formatFlags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return validationErrorf("%s cannot be combined with json output for git %s", arg, command)
+			}
+		}
+	}
+	return nil
+}
+
+// GitFileStatus describes a changed file in git status output
+type GitFileStatus struct {
+	Path     string `json:"path"`
+	OrigPath string `json:"orig_path,omitempty"`
+	Status   string `json:"status"`
+}
+
+// GitStatus is the structured form of git status output
+type GitStatus struct {
+	Staged    []GitFileStatus `json:"staged"`
+	Unstaged  []GitFileStatus `json:"unstaged"`
+	Untracked []string        `json:"untracked"`
+	Unmerged  []string        `json:"unmerged,omitempty"`
+}
+
+// GitCommit is a single entry of structured git log output
+type GitCommit struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+	Subject string `json:"subject"`
+}
+
+// parseGitStatus parses the output of git status --porcelain=v2 -z
+func parseGitStatus(output string) GitStatus {
+	status := GitStatus{
+		Staged:    []GitFileStatus{},
+		Unstaged:  []GitFileStatus{},
+		Untracked: []string{},
+	}
+
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 2 {
+			continue
+		}
+
+		var xy, path, origPath string
+		switch entry[0] {
+		case '1':
+			// 1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
+			fields := strings.SplitN(entry, " ", 9)
+			if len(fields) < 9 {
+				continue
+			}
+			xy, path = fields[1], fields[8]
+		case '2':
+			// 2 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <X><score> <path>, followed by the original path
+			fields := strings.SplitN(entry, " ", 10)
+			if len(fields) < 10 {
+				continue
+			}
+			xy, path = fields[1], fields[9]
+			if i+1 < len(entries) {
+				i++
+				origPath = entries[i]
+			}
+		case 'u':
+			// u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) == 11 {
+				status.Unmerged = append(status.Unmerged, fields[10])
+			}
+			continue
+		case '?':
+			status.Untracked = append(status.Untracked, entry[2:])
+			continue
+		default:
+			continue
+		}
+
+		if len(xy) != 2 {
+			continue
+		}
+		if xy[0] != '.' {
+			status.Staged = append(status.Staged, GitFileStatus{Path: path, OrigPath: origPath, Status: string(xy[0])})
+		}
+		if xy[1] != '.' {
+			status.Unstaged = append(status.Unstaged, GitFileStatus{Path: path, OrigPath: origPath, Status: string(xy[1])})
+		}
+	}
+
+	return status
+}
+
+// parseGitLog parses git log output formatted with unit-separated fields and record-separated commits
+func parseGitLog(output string) []GitCommit {
+	commits := []GitCommit{}
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		commits = append(commits, GitCommit{
+			SHA:     fields[0],
+			Author:  fields[1],
+			Date:    fields[2],
+			Subject: fields[3],
+		})
 	}
+	return commits
 }
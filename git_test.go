@@ -7,11 +7,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewGit(t *testing.T) {
@@ -166,3 +169,606 @@ func TestGit_GitAllInOneTool_Handler(t *testing.T) {
 		})
 	}
 }
+
+func TestGit_BlockedCommands(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", repoPath, "init").Run())
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		blocked bool
+	}{
+		{name: "blocked reset", command: "reset", args: []string{"--hard"}, blocked: true},
+		{name: "blocked reset after a commit", command: "reset", args: []string{"HEAD~1", "--hard"}, blocked: true},
+		{name: "blocked reset after another flag", command: "reset", args: []string{"-q", "--hard"}, blocked: true},
+		{name: "blocked push with different case", command: "PUSH", blocked: true},
+		{name: "blocked command with argument", command: "branch", args: []string{"-D", "main"}, blocked: true},
+		{name: "allowed status", command: "status", blocked: false},
+		{name: "allowed branch listing", command: "branch", args: []string{"--list"}, blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+			logger.On("Debug", mock.Anything).Return().Maybe()
+			logger.On("Info", mock.Anything).Return().Maybe()
+			logger.On("Error", mock.Anything).Return().Maybe()
+
+			git := NewGit(logger, GitConfig{
+				BlockedCommands: []string{"Reset", "push", "branch -D"},
+			})
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command":   tt.command,
+				"repo_path": repoPath,
+				"args":      tt.args,
+			})
+			require.NoError(t, err)
+
+			result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GitToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.blocked, result.IsError)
+			if tt.blocked {
+				assert.Contains(t, result.Content[0].Text, "is blocked")
+			}
+		})
+	}
+}
+
+func TestGit_RejectsGlobalOptionsAsCommand(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", repoPath, "init").Run())
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+	}{
+		{name: "config override running an alias", command: "-c", args: []string{"alias.x=push", "x"}},
+		{name: "directory override", command: "-C", args: []string{"/", "clone", "https://example.com/repo.git", "/tmp/dst"}},
+		{name: "long global option", command: "--git-dir=/tmp/other", args: []string{"status"}},
+		{name: "command with whitespace", command: "status --short"},
+		{name: "empty command", command: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+			logger.On("Info", mock.Anything).Return().Maybe()
+			logger.On("Error", mock.Anything).Return().Maybe()
+
+			git := NewGit(logger, GitConfig{BlockedCommands: []string{"push", "clone"}})
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command":   tt.command,
+				"repo_path": repoPath,
+				"args":      tt.args,
+			})
+			require.NoError(t, err)
+
+			result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GitToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, "invalid git command")
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+		})
+	}
+}
+
+func TestGit_RepoPathResolution(t *testing.T) {
+	defaultRepo := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", defaultRepo, "init").Run())
+	require.NoError(t, os.MkdirAll(filepath.Join(defaultRepo, "nested"), 0755))
+
+	tests := []struct {
+		name          string
+		config        GitConfig
+		repoPath      string
+		expectedError string
+	}{
+		{
+			name:     "falls back to default repo path",
+			config:   GitConfig{DefaultRepoPath: defaultRepo},
+			repoPath: "",
+		},
+		{
+			name:     "relative path within default repo path",
+			config:   GitConfig{DefaultRepoPath: defaultRepo},
+			repoPath: "nested",
+		},
+		{
+			name:          "path outside default repo path",
+			config:        GitConfig{DefaultRepoPath: defaultRepo},
+			repoPath:      t.TempDir(),
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:          "relative path escaping default repo path",
+			config:        GitConfig{DefaultRepoPath: defaultRepo},
+			repoPath:      "../other",
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:          "no repo path and no default",
+			config:        GitConfig{},
+			repoPath:      "",
+			expectedError: "repo_path is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+			logger.On("Debug", mock.Anything).Return().Maybe()
+			logger.On("Info", mock.Anything).Return().Maybe()
+			logger.On("Error", mock.Anything).Return().Maybe()
+
+			git := NewGit(logger, tt.config)
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command":   "status",
+				"repo_path": tt.repoPath,
+			})
+			require.NoError(t, err)
+
+			result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GitToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			if tt.expectedError != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, result.Content[0].Text, tt.expectedError)
+				return
+			}
+			assert.False(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, "On branch")
+		})
+	}
+}
+
+func TestGit_Timeout(t *testing.T) {
+	// A fake git binary that hangs like a fetch against an unreachable remote
+	binDir := t.TempDir()
+	script := "#!/bin/sh\nexec sleep 5\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "git"), []byte(script), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	logger.On("Debug", mock.Anything).Return().Maybe()
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything).Return().Maybe()
+
+	git := NewGit(logger, GitConfig{Timeout: 100 * time.Millisecond})
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"command":   "fetch",
+		"repo_path": t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      GitToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "git command timed out after 100ms")
+}
+
+func TestGit_RemoteRestrictions(t *testing.T) {
+	defaultRepo := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", defaultRepo, "init").Run())
+
+	// A local repository to clone from, so the allowed case needs no network
+	sourceRepo := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", sourceRepo, "init").Run())
+
+	config := GitConfig{
+		DefaultRepoPath:    defaultRepo,
+		AllowedRemoteHosts: []string{"github.com"},
+	}
+
+	tests := []struct {
+		name          string
+		command       string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "clone from an internal host",
+			command:       "clone",
+			args:          []string{"http://10.0.0.5/internal.git"},
+			expectedError: "remote http://10.0.0.5/internal.git is not allowed",
+		},
+		{
+			name:          "clone from an internal host in scp-like form",
+			command:       "clone",
+			args:          []string{"--depth", "1", "git@intranet.local:team/repo.git"},
+			expectedError: "remote git@intranet.local:team/repo.git is not allowed",
+		},
+		{
+			name:          "clone outside the default repository path",
+			command:       "clone",
+			args:          []string{"https://github.com/octocat/hello-world.git", t.TempDir()},
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:          "clone escaping the default repository path",
+			command:       "clone",
+			args:          []string{"https://github.com/octocat/hello-world.git", "../escaped"},
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:          "add a remote on a disallowed host",
+			command:       "remote",
+			args:          []string{"add", "internal", "https://gitlab.internal/repo.git"},
+			expectedError: "remote https://gitlab.internal/repo.git is not allowed",
+		},
+		{
+			name:          "set a remote url on a disallowed host through config",
+			command:       "config",
+			args:          []string{"remote.origin.url", "https://gitlab.internal/repo.git"},
+			expectedError: "remote https://gitlab.internal/repo.git is not allowed",
+		},
+		{
+			name:          "set a push url on a disallowed host through config set",
+			command:       "config",
+			args:          []string{"set", "--local", "Remote.Origin.PushURL", "git@intranet.local:team/repo.git"},
+			expectedError: "remote git@intranet.local:team/repo.git is not allowed",
+		},
+		{
+			name:          "rewrite allowed urls to a disallowed host",
+			command:       "config",
+			args:          []string{"--add", "url.http://10.0.0.5/.insteadOf", "https://github.com/"},
+			expectedError: "remote http://10.0.0.5/ is not allowed",
+		},
+		{
+			name:          "write a config file outside the default repository path",
+			command:       "config",
+			args:          []string{"--file", filepath.Join(t.TempDir(), "config"), "user.name", "octocat"},
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:          "write a config file escaping the default repository path",
+			command:       "config",
+			args:          []string{"-f../escaped.cfg", "user.name", "octocat"},
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:          "write the global config",
+			command:       "config",
+			args:          []string{"--global", "user.name", "octocat"},
+			expectedError: "git config --global is outside the default repository path",
+		},
+		{
+			name:          "add a worktree outside the default repository path",
+			command:       "worktree",
+			args:          []string{"add", "-b", "feature", t.TempDir()},
+			expectedError: "is outside the default repository path",
+		},
+		{
+			name:    "clone a local repository within the default repository path",
+			command: "clone",
+			args:    []string{sourceRepo, "cloned"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+			logger.On("Debug", mock.Anything).Return().Maybe()
+			logger.On("Info", mock.Anything).Return().Maybe()
+			logger.On("Error", mock.Anything).Return().Maybe()
+
+			git := NewGit(logger, config)
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command": tt.command,
+				"args":    tt.args,
+			})
+			require.NoError(t, err)
+
+			result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GitToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			if tt.expectedError != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, result.Content[0].Text, tt.expectedError)
+				kind, ok := ResultErrorKind(result)
+				assert.True(t, ok)
+				assert.Equal(t, ErrorKindPermission, kind)
+				return
+			}
+			assert.False(t, result.IsError, result.Content[0].Text)
+			assert.DirExists(t, filepath.Join(defaultRepo, "cloned", ".git"))
+		})
+	}
+}
+
+func TestGit_RejectsAliasDefinitions(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", repoPath, "init").Run())
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "key and value", args: []string{"alias.p", "push"}},
+		{name: "mixed case key", args: []string{"Alias.P", "push"}},
+		{name: "add", args: []string{"--add", "alias.p", "push"}},
+		{name: "set subcommand", args: []string{"set", "alias.p", "push"}},
+		{name: "section rename", args: []string{"--rename-section", "shortcuts", "alias"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+			logger.On("Info", mock.Anything).Return().Maybe()
+			logger.On("Error", mock.Anything).Return().Maybe()
+
+			git := NewGit(logger, GitConfig{BlockedCommands: []string{"push"}})
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"command":   "config",
+				"repo_path": repoPath,
+				"args":      tt.args,
+			})
+			require.NoError(t, err)
+
+			result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GitToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, "git aliases cannot be defined")
+			assert.Error(t, exec.Command("git", "-C", repoPath, "config", "--get", "alias.p").Run())
+		})
+	}
+}
+
+func TestValidateGitConfigAlias_AllowsReads(t *testing.T) {
+	for _, args := range [][]string{
+		{"--get", "alias.p"},
+		{"--get-regexp", "alias", "push"},
+		{"get", "alias.p"},
+		{"--unset", "alias.p"},
+		{"user.name", "Octo Cat"},
+	} {
+		positional, _ := parseGitArgs(args, gitValueFlags["config"])
+		assert.NoError(t, validateGitConfigAlias(args, positional), args)
+	}
+}
+
+func TestGit_ValidateRemoteAccessRejectsGlobalOptions(t *testing.T) {
+	git := NewGit(new(MockLogger), GitConfig{AllowedRemoteHosts: []string{"github.com"}})
+
+	err := git.validateRemoteAccess("-c", []string{"alias.x=fetch", "x", "http://10.0.0.5/internal.git"}, t.TempDir())
+	require.Error(t, err)
+	kind, ok := ErrorKindOf(err)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindValidation, kind)
+}
+
+func TestGitConfigFiles(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"--file", "a.cfg", "user.name", "x"}, want: []string{"a.cfg"}},
+		{args: []string{"--file=b.cfg", "user.name", "x"}, want: []string{"b.cfg"}},
+		{args: []string{"--fil", "c.cfg", "--list"}, want: []string{"c.cfg"}},
+		{args: []string{"-f", "d.cfg", "--list"}, want: []string{"d.cfg"}},
+		{args: []string{"-fe.cfg", "--list"}, want: []string{"e.cfg"}},
+		{args: []string{"-lf", "f.cfg"}, want: []string{"f.cfg"}},
+		{args: []string{"--fixed-value", "user.name", "x"}},
+		{args: []string{"user.name", "--", "-f"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, gitConfigFiles(tt.args), tt.args)
+	}
+}
+
+func TestGitConfigRemoteURL(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		want  string
+		ok    bool
+	}{
+		{key: "remote.origin.url", value: "https://github.com/a/b.git", want: "https://github.com/a/b.git", ok: true},
+		{key: "remote.my.fork.pushurl", value: "git@github.com:a/b.git", want: "git@github.com:a/b.git", ok: true},
+		{key: "url.https://Mirror.example/.insteadOf", value: "https://github.com/", want: "https://Mirror.example/", ok: true},
+		{key: "url.ssh://git@host/.pushInsteadOf", value: "https://github.com/", want: "ssh://git@host/", ok: true},
+		{key: "remote.origin.fetch", value: "+refs/heads/*:refs/remotes/origin/*"},
+		{key: "user.name", value: "Octo Cat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := gitConfigRemoteURL(tt.key, tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRemoteURLHost(t *testing.T) {
+	tests := []struct {
+		url      string
+		host     string
+		isRemote bool
+	}{
+		{url: "https://github.com/octocat/hello-world.git", host: "github.com", isRemote: true},
+		{url: "ssh://git@GitHub.com:22/octocat/hello-world.git", host: "GitHub.com", isRemote: true},
+		{url: "git@github.com:octocat/hello-world.git", host: "github.com", isRemote: true},
+		{url: "file:///srv/repo.git", host: "", isRemote: true},
+		{url: "ext::sh -c touch% /tmp/pwned", host: "", isRemote: true},
+		{url: "/srv/repo.git", isRemote: false},
+		{url: "./repo", isRemote: false},
+		{url: "origin", isRemote: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			host, isRemote := remoteURLHost(tt.url)
+			assert.Equal(t, tt.isRemote, isRemote)
+			assert.Equal(t, tt.host, host)
+		})
+	}
+}
+
+func TestParseGitStatus(t *testing.T) {
+	output := "1 M. N... 100644 100644 100644 abc def staged.go\x00" +
+		"1 .M N... 100644 100644 100644 abc abc unstaged file.go\x00" +
+		"1 MM N... 100644 100644 100644 abc def both.go\x00" +
+		"2 R. N... 100644 100644 100644 abc abc R100 new.go\x00old.go\x00" +
+		"u UU N... 100644 100644 100644 100644 a b c conflict.go\x00" +
+		"? untracked.txt\x00" +
+		"! ignored.log\x00"
+
+	status := parseGitStatus(output)
+
+	assert.Equal(t, []GitFileStatus{
+		{Path: "staged.go", Status: "M"},
+		{Path: "both.go", Status: "M"},
+		{Path: "new.go", OrigPath: "old.go", Status: "R"},
+	}, status.Staged)
+	assert.Equal(t, []GitFileStatus{
+		{Path: "unstaged file.go", Status: "M"},
+		{Path: "both.go", Status: "M"},
+	}, status.Unstaged)
+	assert.Equal(t, []string{"untracked.txt"}, status.Untracked)
+	assert.Equal(t, []string{"conflict.go"}, status.Unmerged)
+}
+
+func TestParseGitLog(t *testing.T) {
+	output := "abc123\x1fJane Doe\x1f2024-01-02T03:04:05+00:00\x1fAdd feature\x1e\n" +
+		"def456\x1fJohn Roe\x1f2024-01-01T00:00:00+00:00\x1fFix: handle a|b\x1e"
+
+	commits := parseGitLog(output)
+
+	assert.Equal(t, []GitCommit{
+		{SHA: "abc123", Author: "Jane Doe", Date: "2024-01-02T03:04:05+00:00", Subject: "Add feature"},
+		{SHA: "def456", Author: "John Roe", Date: "2024-01-01T00:00:00+00:00", Subject: "Fix: handle a|b"},
+	}, commits)
+}
+
+func TestGit_JSONOutput(t *testing.T) {
+	repoPath := t.TempDir()
+	runGit := func(args ...string) {
+		output, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	runGit("init")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "commit.gpgsign", "false")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "tracked.txt"), []byte("v1"), 0644))
+	runGit("add", ".")
+	runGit("commit", "-m", "Initial commit")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "tracked.txt"), []byte("v2"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "new.txt"), []byte("new"), 0644))
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	logger.On("Debug", mock.Anything).Return().Maybe()
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything).Return().Maybe()
+
+	git := NewGit(logger, GitConfig{DefaultRepoPath: repoPath})
+
+	runTool := func(command string) string {
+		inputJSON, err := json.Marshal(map[string]interface{}{"command": command, "json": true})
+		require.NoError(t, err)
+
+		result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+			Name:      GitToolName,
+			Arguments: inputJSON,
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content[0].Text)
+		return result.Content[0].Text
+	}
+
+	var status GitStatus
+	require.NoError(t, json.Unmarshal([]byte(runTool("status")), &status))
+	assert.Empty(t, status.Staged)
+	assert.Equal(t, []GitFileStatus{{Path: "tracked.txt", Status: "M"}}, status.Unstaged)
+	assert.Equal(t, []string{"new.txt"}, status.Untracked)
+
+	var commits []GitCommit
+	require.NoError(t, json.Unmarshal([]byte(runTool("log")), &commits))
+	require.Len(t, commits, 1)
+	assert.Len(t, commits[0].SHA, 40)
+	assert.Equal(t, "Test User", commits[0].Author)
+	assert.Equal(t, "Initial commit", commits[0].Subject)
+	assert.NotEmpty(t, commits[0].Date)
+}
+
+func TestGit_JSONOutputRejectsFormatFlags(t *testing.T) {
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	logger.On("Debug", mock.Anything).Return().Maybe()
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything).Return().Maybe()
+
+	git := NewGit(logger, GitConfig{DefaultRepoPath: t.TempDir()})
+
+	tests := []struct {
+		command string
+		args    []string
+	}{
+		{command: "log", args: []string{"--format=%H"}},
+		{command: "log", args: []string{"-n", "5", "--pretty", "oneline"}},
+		{command: "log", args: []string{"--oneline"}},
+		{command: "status", args: []string{"--short"}},
+		{command: "status", args: []string{"--porcelain=v1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command+" "+strings.Join(tt.args, " "), func(t *testing.T) {
+			inputJSON, err := json.Marshal(map[string]interface{}{"command": tt.command, "args": tt.args, "json": true})
+			require.NoError(t, err)
+
+			result, err := git.GitAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GitToolName,
+				Arguments: inputJSON,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, "cannot be combined with json output")
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+		})
+	}
+}
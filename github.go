@@ -3,6 +3,10 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/shaharia-lab/goai"
@@ -14,6 +18,9 @@ const (
 	GitHubPullRequestsToolName = "github_pull_requests"
 	GitHubRepositoryToolName   = "github_repository"
 	GitHubSearchToolName       = "github_search"
+	GitHubLabelsToolName       = "github_labels"
+	GitHubGistsToolName        = "github_gists"
+	GitHubRateLimitToolName    = "github_rate_limit"
 )
 
 // GitHub represents a wrapper around GitHub API client
@@ -23,10 +30,33 @@ type GitHub struct {
 	config GitHubConfig
 }
 
+// GitHubConfig holds the configuration for the GitHub tools
 type GitHubConfig struct {
 	Token string
+
+	// BaseURL and UploadURL point the client at a GitHub Enterprise Server instance.
+	// When empty, the public github.com API is used. UploadURL defaults to BaseURL.
+	// When they are invalid, every request fails rather than reaching github.com.
+	BaseURL   string
+	UploadURL string
+
+	// MaxRetries is the number of times a request is retried after hitting a rate limit.
+	// Defaults to 1 when zero; set a negative value to disable retries.
+	MaxRetries int
+	// MaxRetryWait is the longest the tools will wait for a rate limit to reset
+	// before giving up. Defaults to one minute when zero.
+	MaxRetryWait time.Duration
 }
 
+const (
+	defaultGitHubMaxRetries   = 1
+	defaultGitHubMaxRetryWait = time.Minute
+)
+
+// errUnsupportedOperation is returned from within rate limit retries when the
+// requested operation is not supported by the tool
+var errUnsupportedOperation = errors.New("unsupported operation")
+
 // NewGitHubTool to perform operations on GitHub
 func NewGitHubTool(logger goai.Logger, config GitHubConfig) *GitHub {
 	ctx := context.Background()
@@ -36,6 +66,29 @@ func NewGitHubTool(logger goai.Logger, config GitHubConfig) *GitHub {
 	tc := oauth2.NewClient(ctx, ts)
 	client := github.NewClient(tc)
 
+	if config.BaseURL != "" {
+		uploadURL := config.UploadURL
+		if uploadURL == "" {
+			uploadURL = config.BaseURL
+		}
+
+		enterpriseClient, err := client.WithEnterpriseURLs(config.BaseURL, uploadURL)
+		if err != nil {
+			logger.WithFields(map[string]interface{}{
+				goai.ErrorLogField: err,
+				"base_url":         config.BaseURL,
+				"upload_url":       uploadURL,
+			}).Error("Invalid GitHub Enterprise URLs, GitHub requests will fail")
+
+			// Fail closed: falling back to github.com would send the Enterprise token there
+			client = github.NewClient(&http.Client{Transport: failingTransport{
+				err: newToolError(ErrorKindInternal, fmt.Errorf("invalid GitHub Enterprise URLs: %w", err)),
+			}})
+		} else {
+			client = enterpriseClient
+		}
+	}
+
 	return &GitHub{
 		client: client,
 		logger: logger,
@@ -43,6 +96,124 @@ func NewGitHubTool(logger goai.Logger, config GitHubConfig) *GitHub {
 	}
 }
 
+// failingTransport fails every request with err without sending it
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// Tools returns all GitHub tools, e.g. for registering them in a ToolRegistry
+func (g *GitHub) Tools() []goai.Tool {
+	return []goai.Tool{
+		g.GetIssuesTool(),
+		g.GetPullRequestsTool(),
+		g.GetRepositoryTool(),
+		g.GetSearchTool(),
+		g.GetLabelsTool(),
+		g.GetGistsTool(),
+		g.GetRateLimitTool(),
+	}
+}
+
+// paginatedResult builds a JSON tool result from the marshalled response and,
+// when the GitHub API reports more pages, appends the next page number
+func paginatedResult(marshalled string, resp *github.Response) goai.CallToolResult {
+	result := goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: marshalled,
+		}},
+	}
+
+	if resp != nil && resp.NextPage != 0 {
+		result.Content = append(result.Content, goai.ToolResultContent{
+			Type: "text",
+			Text: fmt.Sprintf("next_page: %d", resp.NextPage),
+		})
+	}
+
+	return result
+}
+
+// retryOnRateLimit runs fn and, if it fails due to a primary or secondary GitHub rate
+// limit, waits until the limit resets and retries it. It shares retryLoop with
+// the other HTTP tools, so the wait is bounded by the configured MaxRetryWait
+// and the context deadline.
+func (g *GitHub) retryOnRateLimit(ctx context.Context, fn func() error) error {
+	maxRetries := g.config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultGitHubMaxRetries
+	}
+
+	maxWait := g.config.MaxRetryWait
+	if maxWait <= 0 {
+		maxWait = defaultGitHubMaxRetryWait
+	}
+
+	var err error
+	loopErr := retryLoop(ctx, RetryConfig{MaxAttempts: maxRetries + 1, MaxDelay: maxWait}, func() (bool, *time.Duration) {
+		err = fn()
+		if err == nil {
+			return false, nil
+		}
+		wait, ok := rateLimitWait(err)
+		return ok, &wait
+	}, func(attempt int, wait time.Duration) {
+		g.logger.WithFields(map[string]interface{}{
+			goai.ErrorLogField: err,
+			"attempt":          attempt,
+			"wait":             wait.String(),
+		}).Warn("GitHub rate limit hit, retrying after reset")
+	})
+	if loopErr != nil {
+		return loopErr
+	}
+	return err
+}
+
+// rateLimitWait returns how long to wait before retrying a request that failed
+// with a rate limit error. It reports false if err is not a rate limit error.
+func rateLimitWait(err error) (time.Duration, bool) {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		wait := time.Until(rateLimitErr.Rate.Reset.Time)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if abuseErr.RetryAfter != nil {
+			return *abuseErr.RetryAfter, true
+		}
+		return defaultGitHubMaxRetryWait, true
+	}
+
+	return 0, false
+}
+
+// githubToolError classifies a failed GitHub API call by its HTTP status
+func githubToolError(err error) error {
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		switch errResp.Response.StatusCode {
+		case http.StatusNotFound:
+			return newToolError(ErrorKindNotFound, err)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return newToolError(ErrorKindPermission, err)
+		}
+	}
+	return newToolError(ErrorKindUpstream, err)
+}
+
 // Helper function for JSON marshaling
 func mustMarshal(v interface{}) string {
 	b, err := json.Marshal(v)
@@ -0,0 +1,219 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// gistSummary is the result of the create, update and list gist operations
+type gistSummary struct {
+	ID          string   `json:"id"`
+	URL         string   `json:"url"`
+	Description string   `json:"description,omitempty"`
+	Public      bool     `json:"public"`
+	Files       []string `json:"files"`
+}
+
+// GetGistsTool returns a tool for managing GitHub gists
+func (g *GitHub) GetGistsTool() goai.Tool {
+	return withInputValidation(g.logger, goai.Tool{
+		Name:        GitHubGistsToolName,
+		Description: "Manages GitHub gists - create, get, list, update and delete gists",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["create", "get", "list", "update", "delete"],
+					"description": "Gist operation to perform"
+				},
+				"gist_id": {
+					"type": "string",
+					"description": "Gist ID (for get, update and delete)"
+				},
+				"files": {
+					"type": "object",
+					"additionalProperties": {"type": "string"},
+					"description": "Map of file name to file content (for create and update)"
+				},
+				"description": {
+					"type": "string",
+					"description": "Gist description (for create and update)"
+				},
+				"public": {
+					"type": "boolean",
+					"description": "Whether the gist is public (for create, default: false)"
+				},
+				"user": {
+					"type": "string",
+					"description": "List the public gists of this user instead of the authenticated user's gists (for list)"
+				},
+				"page": {
+					"type": "integer",
+					"description": "Page number of results to fetch for list"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of results per page for list (max 100)"
+				}
+			},
+			"required": ["operation"]
+		}`),
+		Handler: g.handleGistsOperation,
+	})
+}
+
+func (g *GitHub) handleGistsOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool_name": params.Name,
+		"operation": params.Arguments,
+	}).Info("handling gists operation")
+
+	var input struct {
+		Operation   string            `json:"operation"`
+		GistID      string            `json:"gist_id"`
+		Files       map[string]string `json:"files"`
+		Description string            `json:"description"`
+		Public      bool              `json:"public"`
+		User        string            `json:"user"`
+		Page        int               `json:"page"`
+		PerPage     int               `json:"per_page"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+	}
+
+	switch input.Operation {
+	case "get", "update", "delete":
+		if input.GistID == "" {
+			return returnErrorOutput(validationErrorf("gist_id is required for %s", input.Operation)), nil
+		}
+	case "create":
+		if len(input.Files) == 0 {
+			return returnErrorOutput(validationErrorf("files are required for create")), nil
+		}
+	}
+
+	var result interface{}
+	var resp *github.Response
+
+	err := g.retryOnRateLimit(ctx, func() error {
+		switch input.Operation {
+		case "create":
+			gist, _, err := g.client.Gists.Create(ctx, &github.Gist{
+				Description: github.String(input.Description),
+				Public:      github.Bool(input.Public),
+				Files:       gistFilesFromInput(input.Files),
+			})
+			if err != nil {
+				return err
+			}
+			result = summarizeGist(gist)
+		case "get":
+			gist, _, err := g.client.Gists.Get(ctx, input.GistID)
+			if err != nil {
+				return err
+			}
+			result = gist
+		case "list":
+			gists, listResp, err := g.client.Gists.List(ctx, input.User, &github.GistListOptions{
+				ListOptions: github.ListOptions{
+					Page:    input.Page,
+					PerPage: input.PerPage,
+				},
+			})
+			if err != nil {
+				return err
+			}
+			summaries := make([]gistSummary, 0, len(gists))
+			for _, gist := range gists {
+				summaries = append(summaries, summarizeGist(gist))
+			}
+			result, resp = summaries, listResp
+		case "update":
+			update := &github.Gist{Files: gistFilesFromInput(input.Files)}
+			if input.Description != "" {
+				update.Description = github.String(input.Description)
+			}
+			gist, _, err := g.client.Gists.Edit(ctx, input.GistID, update)
+			if err != nil {
+				return err
+			}
+			result = summarizeGist(gist)
+		case "delete":
+			if _, err := g.client.Gists.Delete(ctx, input.GistID); err != nil {
+				return err
+			}
+			result = map[string]string{"status": "deleted"}
+		default:
+			return errUnsupportedOperation
+		}
+		return nil
+	})
+	if errors.Is(err, errUnsupportedOperation) {
+		return returnErrorOutput(validationErrorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub gists operation failed")
+
+		return returnErrorOutput(githubToolError(err)), nil
+	}
+
+	marshalledResult := mustMarshal(result)
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(marshalledResult),
+	}).Info("GitHub gists operation completed successfully")
+
+	return paginatedResult(marshalledResult, resp), nil
+}
+
+// gistFilesFromInput converts a file name to content map into gist files
+func gistFilesFromInput(files map[string]string) map[github.GistFilename]github.GistFile {
+	if len(files) == 0 {
+		return nil
+	}
+
+	gistFiles := make(map[github.GistFilename]github.GistFile, len(files))
+	for name, content := range files {
+		gistFiles[github.GistFilename(name)] = github.GistFile{
+			Filename: github.String(name),
+			Content:  github.String(content),
+		}
+	}
+	return gistFiles
+}
+
+// summarizeGist keeps the identifying fields of a gist and its sorted file names
+func summarizeGist(gist *github.Gist) gistSummary {
+	files := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		files = append(files, string(name))
+	}
+	sort.Strings(files)
+
+	return gistSummary{
+		ID:          gist.GetID(),
+		URL:         gist.GetHTMLURL(),
+		Description: gist.GetDescription(),
+		Public:      gist.GetPublic(),
+		Files:       files,
+	}
+}
@@ -0,0 +1,137 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newGistsTestLogger() *MockLogger {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling gists operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub gists operation completed successfully"}).Return()
+	return mockLogger
+}
+
+func TestHandleGistsOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newGistsTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/gists", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var gist github.Gist
+		err := json.NewDecoder(r.Body).Decode(&gist)
+		assert.NoError(t, err)
+		assert.Equal(t, "Build notes", gist.GetDescription())
+		assert.True(t, gist.GetPublic())
+		file := gist.Files["build.sh"]
+		assert.Equal(t, "go build ./...", file.GetContent())
+
+		gist.ID = github.String("abc123")
+		gist.HTMLURL = github.String("https://gist.github.com/abc123")
+		err = json.NewEncoder(w).Encode(gist)
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation":   "create",
+		"description": "Build notes",
+		"public":      true,
+		"files":       map[string]string{"build.sh": "go build ./..."},
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleGistsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubGistsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var summary gistSummary
+	err = json.Unmarshal([]byte(result.Content[0].Text), &summary)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", summary.ID)
+	assert.Equal(t, "https://gist.github.com/abc123", summary.URL)
+	assert.Equal(t, []string{"build.sh"}, summary.Files)
+}
+
+func TestHandleGistsOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newGistsTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/users/octocat/gists", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+
+		w.Header().Set("Link", fmt.Sprintf(`<%s/users/octocat/gists?page=2>; rel="next"`, server.URL))
+		err := json.NewEncoder(w).Encode([]*github.Gist{
+			{ID: github.String("g1"), HTMLURL: github.String("https://gist.github.com/g1"), Public: github.Bool(true)},
+			{ID: github.String("g2"), HTMLURL: github.String("https://gist.github.com/g2"), Public: github.Bool(true)},
+		})
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "list",
+		"user":      "octocat",
+		"per_page":  2,
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleGistsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubGistsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+
+	var summaries []gistSummary
+	err = json.Unmarshal([]byte(result.Content[0].Text), &summaries)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "g1", summaries[0].ID)
+	assert.Equal(t, "https://gist.github.com/g2", summaries[1].URL)
+	assert.Equal(t, "next_page: 2", result.Content[1].Text)
+}
+
+func TestHandleGistsOperation_RequiresGistID(t *testing.T) {
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = newGistsTestLogger()
+	defer cleanup()
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "delete",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleGistsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubGistsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "gist_id is required for delete", result.Content[0].Text)
+}
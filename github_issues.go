@@ -3,7 +3,9 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/shaharia-lab/goai"
@@ -11,15 +13,15 @@ import (
 
 // GetIssuesTool returns a tool for managing GitHub issues
 func (g *GitHub) GetIssuesTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GitHubIssuesToolName,
-		Description: "Manages GitHub issues - create, list, update, comment",
+		Description: "Manages GitHub issues - create, list, update, comment, lock, unlock and react",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["create", "get", "list", "update", "comment", "close"],
+					"enum": ["create", "get", "list", "update", "comment", "list_comments", "close", "lock", "unlock", "add_reaction"],
 					"description": "Issue operation to perform"
 				},
 				"owner": {
@@ -45,18 +47,49 @@ func (g *GitHub) GetIssuesTool() goai.Tool {
 				"labels": {
 					"type": "array",
 					"items": {"type": "string"},
-					"description": "Issue labels"
+					"description": "Issue labels. For list operation, filters issues having all of these labels"
 				},
 				"assignees": {
 					"type": "array",
 					"items": {"type": "string"},
 					"description": "Issue assignees"
+				},
+				"state": {
+					"type": "string",
+					"enum": ["open", "closed", "all"],
+					"description": "Filter issues by state for list operation (default: open)"
+				},
+				"assignee": {
+					"type": "string",
+					"description": "Filter issues by assignee login for list operation. Use 'none' for unassigned or '*' for any"
+				},
+				"since": {
+					"type": "string",
+					"description": "Only list issues updated at or after this time (RFC3339, e.g. 2024-01-01T00:00:00Z)"
+				},
+				"lock_reason": {
+					"type": "string",
+					"enum": ["off-topic", "too heated", "resolved", "spam"],
+					"description": "Reason shown on the issue for lock operation (optional)"
+				},
+				"reaction": {
+					"type": "string",
+					"enum": ["+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"],
+					"description": "Reaction to add to the issue for add_reaction operation"
+				},
+				"page": {
+					"type": "integer",
+					"description": "Page number of results to fetch for list operations"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of results per page for list operations (max 100)"
 				}
 			},
 			"required": ["operation", "owner", "repo"]
 		}`),
 		Handler: g.handleIssuesOperation,
-	}
+	})
 }
 
 func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
@@ -69,53 +102,110 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 	}).Info("handling issues operation")
 
 	var input struct {
-		Operation string   `json:"operation"`
-		Owner     string   `json:"owner"`
-		Repo      string   `json:"repo"`
-		Number    int      `json:"number"`
-		Title     string   `json:"title"`
-		Body      string   `json:"body"`
-		Labels    []string `json:"labels"`
-		Assignees []string `json:"assignees"`
+		Operation  string   `json:"operation"`
+		Owner      string   `json:"owner"`
+		Repo       string   `json:"repo"`
+		Number     int      `json:"number"`
+		Title      string   `json:"title"`
+		Body       string   `json:"body"`
+		Labels     []string `json:"labels"`
+		Assignees  []string `json:"assignees"`
+		State      string   `json:"state"`
+		Assignee   string   `json:"assignee"`
+		Since      string   `json:"since"`
+		LockReason string   `json:"lock_reason"`
+		Reaction   string   `json:"reaction"`
+		Page       int      `json:"page"`
+		PerPage    int      `json:"per_page"`
 	}
 
 	if err := json.Unmarshal(params.Arguments, &input); err != nil {
-		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+		return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+	}
+
+	var since time.Time
+	if input.Since != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, input.Since)
+		if err != nil {
+			return returnErrorOutput(validationErrorf("invalid since value %q, expected RFC3339 format: %w", input.Since, err)), nil
+		}
+	}
+
+	if input.Operation == "add_reaction" && input.Reaction == "" {
+		return returnErrorOutput(validationErrorf("reaction is required for add_reaction")), nil
 	}
 
 	var result interface{}
-	var err error
-
-	switch input.Operation {
-	case "create":
-		result, _, err = g.client.Issues.Create(ctx, input.Owner, input.Repo, &github.IssueRequest{
-			Title:     &input.Title,
-			Body:      &input.Body,
-			Labels:    &input.Labels,
-			Assignees: &input.Assignees,
-		})
-	case "get":
-		result, _, err = g.client.Issues.Get(ctx, input.Owner, input.Repo, input.Number)
-	case "list":
-		result, _, err = g.client.Issues.ListByRepo(ctx, input.Owner, input.Repo, &github.IssueListByRepoOptions{})
-	case "update":
-		result, _, err = g.client.Issues.Edit(ctx, input.Owner, input.Repo, input.Number, &github.IssueRequest{
-			Title:     &input.Title,
-			Body:      &input.Body,
-			Labels:    &input.Labels,
-			Assignees: &input.Assignees,
-		})
-	case "comment":
-		result, _, err = g.client.Issues.CreateComment(ctx, input.Owner, input.Repo, input.Number, &github.IssueComment{
-			Body: &input.Body,
-		})
-	case "close":
-		state := "closed"
-		result, _, err = g.client.Issues.Edit(ctx, input.Owner, input.Repo, input.Number, &github.IssueRequest{
-			State: &state,
-		})
-	default:
-		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	var resp *github.Response
+
+	err := g.retryOnRateLimit(ctx, func() error {
+		var err error
+		switch input.Operation {
+		case "create":
+			result, _, err = g.client.Issues.Create(ctx, input.Owner, input.Repo, &github.IssueRequest{
+				Title:     &input.Title,
+				Body:      &input.Body,
+				Labels:    &input.Labels,
+				Assignees: &input.Assignees,
+			})
+		case "get":
+			result, _, err = g.client.Issues.Get(ctx, input.Owner, input.Repo, input.Number)
+		case "list":
+			opts := &github.IssueListByRepoOptions{
+				State:       input.State,
+				Labels:      input.Labels,
+				Assignee:    input.Assignee,
+				ListOptions: github.ListOptions{Page: input.Page, PerPage: input.PerPage},
+			}
+			if opts.State == "" {
+				opts.State = "open"
+			}
+			opts.Since = since
+			result, resp, err = g.client.Issues.ListByRepo(ctx, input.Owner, input.Repo, opts)
+		case "update":
+			result, _, err = g.client.Issues.Edit(ctx, input.Owner, input.Repo, input.Number, &github.IssueRequest{
+				Title:     &input.Title,
+				Body:      &input.Body,
+				Labels:    &input.Labels,
+				Assignees: &input.Assignees,
+			})
+		case "comment":
+			result, _, err = g.client.Issues.CreateComment(ctx, input.Owner, input.Repo, input.Number, &github.IssueComment{
+				Body: &input.Body,
+			})
+		case "list_comments":
+			result, resp, err = g.client.Issues.ListComments(ctx, input.Owner, input.Repo, input.Number, &github.IssueListCommentsOptions{
+				ListOptions: github.ListOptions{Page: input.Page, PerPage: input.PerPage},
+			})
+		case "close":
+			state := "closed"
+			result, _, err = g.client.Issues.Edit(ctx, input.Owner, input.Repo, input.Number, &github.IssueRequest{
+				State: &state,
+			})
+		case "lock":
+			var opts *github.LockIssueOptions
+			if input.LockReason != "" {
+				opts = &github.LockIssueOptions{LockReason: input.LockReason}
+			}
+			_, err = g.client.Issues.Lock(ctx, input.Owner, input.Repo, input.Number, opts)
+			if err == nil {
+				result = map[string]string{"status": "locked"}
+			}
+		case "unlock":
+			_, err = g.client.Issues.Unlock(ctx, input.Owner, input.Repo, input.Number)
+			if err == nil {
+				result = map[string]string{"status": "unlocked"}
+			}
+		case "add_reaction":
+			result, _, err = g.client.Reactions.CreateIssueReaction(ctx, input.Owner, input.Repo, input.Number, input.Reaction)
+		default:
+			return errUnsupportedOperation
+		}
+		return err
+	})
+	if errors.Is(err, errUnsupportedOperation) {
+		return returnErrorOutput(validationErrorf("unsupported operation: %s", input.Operation)), nil
 	}
 
 	if err != nil {
@@ -125,7 +215,7 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 			"operation":                 input.Operation,
 		}).Error("GitHub issues operation failed")
 
-		return returnErrorOutput(err), nil
+		return returnErrorOutput(githubToolError(err)), nil
 	}
 
 	marshalledResult := mustMarshal(result)
@@ -136,10 +226,5 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 		"result_length": len(marshalledResult),
 	}).Info("GitHub issues operation completed successfully")
 
-	return goai.CallToolResult{
-		Content: []goai.ToolResultContent{{
-			Type: "json",
-			Text: marshalledResult,
-		}},
-	}, nil
+	return paginatedResult(marshalledResult, resp), nil
 }
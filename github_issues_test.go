@@ -345,3 +345,311 @@ func TestHandleIssuesOperation_Close(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "closed", *responseIssue.State)
 }
+
+func TestHandleIssuesOperation_ListPagination(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling issues operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub issues operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		assert.Equal(t, "50", r.URL.Query().Get("per_page"))
+
+		w.Header().Set("Link", `<https://api.github.com/repos/test-owner/test-repo/issues?page=3&per_page=50>; rel="next"`)
+		issues := []*github.Issue{{Number: github.Int(51)}}
+		err := json.NewEncoder(w).Encode(issues)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"page":      2,
+		"per_page":  50,
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "next_page: 3", result.Content[1].Text)
+}
+
+func TestHandleIssuesOperation_ListFilters(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling issues operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub issues operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		query := r.URL.Query()
+		assert.Equal(t, "closed", query.Get("state"))
+		assert.Equal(t, "bug", query.Get("labels"))
+		assert.Equal(t, "testuser", query.Get("assignee"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", query.Get("since"))
+
+		issues := []*github.Issue{{Number: github.Int(1), State: github.String("closed")}}
+		err := json.NewEncoder(w).Encode(issues)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"state":     "closed",
+		"labels":    []string{"bug"},
+		"assignee":  "testuser",
+		"since":     "2024-01-01T00:00:00Z",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestHandleIssuesOperation_ListDefaultsToOpenState(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling issues operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub issues operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+
+		err := json.NewEncoder(w).Encode([]*github.Issue{})
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestHandleIssuesOperation_ListComments(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling issues operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub issues operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		comments := []*github.IssueComment{
+			{ID: github.Int64(1), Body: github.String("First comment")},
+			{ID: github.Int64(2), Body: github.String("Second comment")},
+		}
+		err := json.NewEncoder(w).Encode(comments)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "list_comments",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    1,
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var comments []*github.IssueComment
+	err = json.Unmarshal([]byte(result.Content[0].Text), &comments)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "First comment", comments[0].GetBody())
+	assert.Equal(t, "Second comment", comments[1].GetBody())
+}
+
+func newIssuesTestLogger() *MockLogger {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling issues operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub issues operation completed successfully"}).Return()
+	return mockLogger
+}
+
+func TestHandleIssuesOperation_Lock(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newIssuesTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	locked := false
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/5/lock", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "too heated", body["lock_reason"])
+
+		locked = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation":   "lock",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"number":      5,
+		"lock_reason": "too heated",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.True(t, locked)
+	assert.JSONEq(t, `{"status":"locked"}`, result.Content[0].Text)
+}
+
+func TestHandleIssuesOperation_Unlock(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newIssuesTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	unlocked := false
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/5/lock", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		unlocked = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "unlock",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    5,
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.True(t, unlocked)
+	assert.JSONEq(t, `{"status":"unlocked"}`, result.Content[0].Text)
+}
+
+func TestHandleIssuesOperation_AddReaction(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newIssuesTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/5/reactions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "rocket", body["content"])
+
+		err = json.NewEncoder(w).Encode(&github.Reaction{ID: github.Int64(9), Content: github.String("rocket")})
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "add_reaction",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    5,
+		"reaction":  "rocket",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubIssuesToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var reaction github.Reaction
+	err = json.Unmarshal([]byte(result.Content[0].Text), &reaction)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), reaction.GetID())
+	assert.Equal(t, "rocket", reaction.GetContent())
+}
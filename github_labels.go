@@ -0,0 +1,162 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// GetLabelsTool returns a tool for managing GitHub repository labels
+func (g *GitHub) GetLabelsTool() goai.Tool {
+	return withInputValidation(g.logger, goai.Tool{
+		Name:        GitHubLabelsToolName,
+		Description: "Manages GitHub labels - list, create, update, delete repository labels and add or remove them on issues",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list", "create", "update", "delete", "add_to_issue", "remove_from_issue"],
+					"description": "Label operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"name": {
+					"type": "string",
+					"description": "Label name"
+				},
+				"color": {
+					"type": "string",
+					"description": "Label color as a hex code without the leading #, e.g. f29513 (for create and update)"
+				},
+				"description": {
+					"type": "string",
+					"description": "Label description (for create and update)"
+				},
+				"number": {
+					"type": "integer",
+					"description": "Issue or pull request number (for add_to_issue and remove_from_issue)"
+				},
+				"page": {
+					"type": "integer",
+					"description": "Page number of results to fetch for list"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of results per page for list (max 100)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleLabelsOperation,
+	})
+}
+
+func (g *GitHub) handleLabelsOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool_name": params.Name,
+		"operation": params.Arguments,
+	}).Info("handling labels operation")
+
+	var input struct {
+		Operation   string `json:"operation"`
+		Owner       string `json:"owner"`
+		Repo        string `json:"repo"`
+		Name        string `json:"name"`
+		Color       string `json:"color"`
+		Description string `json:"description"`
+		Number      int    `json:"number"`
+		Page        int    `json:"page"`
+		PerPage     int    `json:"per_page"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+	}
+
+	if input.Operation != "list" && input.Name == "" {
+		return returnErrorOutput(validationErrorf("name is required for %s", input.Operation)), nil
+	}
+
+	var result interface{}
+	var resp *github.Response
+
+	err := g.retryOnRateLimit(ctx, func() error {
+		var err error
+		switch input.Operation {
+		case "list":
+			result, resp, err = g.client.Issues.ListLabels(ctx, input.Owner, input.Repo, &github.ListOptions{
+				Page:    input.Page,
+				PerPage: input.PerPage,
+			})
+		case "create":
+			result, _, err = g.client.Issues.CreateLabel(ctx, input.Owner, input.Repo, labelFromInput(input.Name, input.Color, input.Description))
+		case "update":
+			result, _, err = g.client.Issues.EditLabel(ctx, input.Owner, input.Repo, input.Name, labelFromInput(input.Name, input.Color, input.Description))
+		case "delete":
+			_, err = g.client.Issues.DeleteLabel(ctx, input.Owner, input.Repo, input.Name)
+			if err == nil {
+				result = map[string]string{"status": "deleted"}
+			}
+		case "add_to_issue":
+			result, _, err = g.client.Issues.AddLabelsToIssue(ctx, input.Owner, input.Repo, input.Number, []string{input.Name})
+		case "remove_from_issue":
+			_, err = g.client.Issues.RemoveLabelForIssue(ctx, input.Owner, input.Repo, input.Number, input.Name)
+			if err == nil {
+				result = map[string]string{"status": "removed"}
+			}
+		default:
+			return errUnsupportedOperation
+		}
+		return err
+	})
+	if errors.Is(err, errUnsupportedOperation) {
+		return returnErrorOutput(validationErrorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub labels operation failed")
+
+		return returnErrorOutput(githubToolError(err)), nil
+	}
+
+	marshalledResult := mustMarshal(result)
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(marshalledResult),
+	}).Info("GitHub labels operation completed successfully")
+
+	return paginatedResult(marshalledResult, resp), nil
+}
+
+// labelFromInput builds a label, leaving out the color and description when they
+// are empty so that updates keep their current values
+func labelFromInput(name, color, description string) *github.Label {
+	label := &github.Label{Name: github.String(name)}
+	if color != "" {
+		label.Color = github.String(color)
+	}
+	if description != "" {
+		label.Description = github.String(description)
+	}
+	return label
+}
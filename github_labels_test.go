@@ -0,0 +1,136 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newLabelsTestLogger() *MockLogger {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling labels operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub labels operation completed successfully"}).Return()
+	return mockLogger
+}
+
+func TestHandleLabelsOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newLabelsTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var label github.Label
+		err := json.NewDecoder(r.Body).Decode(&label)
+		assert.NoError(t, err)
+		assert.Equal(t, "needs-triage", label.GetName())
+		assert.Equal(t, "f29513", label.GetColor())
+		assert.Equal(t, "Waiting for triage", label.GetDescription())
+
+		label.ID = github.Int64(1)
+		err = json.NewEncoder(w).Encode(label)
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation":   "create",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"name":        "needs-triage",
+		"color":       "f29513",
+		"description": "Waiting for triage",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleLabelsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubLabelsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var label github.Label
+	err = json.Unmarshal([]byte(result.Content[0].Text), &label)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), label.GetID())
+	assert.Equal(t, "needs-triage", label.GetName())
+}
+
+func TestHandleLabelsOperation_AddToIssue(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newLabelsTestLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/7/labels", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var names []string
+		err := json.NewDecoder(r.Body).Decode(&names)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"bug"}, names)
+
+		err = json.NewEncoder(w).Encode([]*github.Label{{Name: github.String("bug")}})
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "add_to_issue",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"name":      "bug",
+		"number":    7,
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleLabelsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubLabelsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var labels []*github.Label
+	err = json.Unmarshal([]byte(result.Content[0].Text), &labels)
+	require.NoError(t, err)
+	require.Len(t, labels, 1)
+	assert.Equal(t, "bug", labels[0].GetName())
+}
+
+func TestHandleLabelsOperation_NameRequired(t *testing.T) {
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = newLabelsTestLogger()
+	defer cleanup()
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "delete",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleLabelsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubLabelsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "name is required for delete", result.Content[0].Text)
+}
@@ -3,6 +3,7 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/go-github/v60/github"
@@ -11,15 +12,15 @@ import (
 
 // GetPullRequestsTool returns a tool for managing GitHub pull requests
 func (g *GitHub) GetPullRequestsTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GitHubPullRequestsToolName,
-		Description: "Manages GitHub pull requests - create, review, merge",
+		Description: "Manages GitHub pull requests - create, review, request reviewers, dismiss reviews, merge",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["create", "get", "list", "update", "merge", "review", "list_files"],
+					"enum": ["create", "get", "list", "update", "merge", "review", "request_reviewers", "dismiss_review", "list_files"],
 					"description": "Pull request operation to perform"
 				},
 				"owner": {
@@ -58,12 +59,51 @@ func (g *GitHub) GetPullRequestsTool() goai.Tool {
 					"type": "string",
 					"enum": ["APPROVE", "REQUEST_CHANGES", "COMMENT"],
 					"description": "Review event type"
+				},
+				"draft": {
+					"type": "boolean",
+					"description": "Create the pull request as a draft"
+				},
+				"reviewers": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "User logins to request reviews from, for create and request_reviewers operations"
+				},
+				"team_reviewers": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Team slugs to request reviews from, for request_reviewers operation"
+				},
+				"review_id": {
+					"type": "integer",
+					"description": "ID of the review to dismiss, for dismiss_review operation"
+				},
+				"message": {
+					"type": "string",
+					"description": "Reason for dismissing the review, for dismiss_review operation"
+				},
+				"merge_method": {
+					"type": "string",
+					"enum": ["merge", "squash", "rebase"],
+					"description": "Merge method for merge operation (default: merge)"
+				},
+				"commit_title": {
+					"type": "string",
+					"description": "Title for the merge commit"
+				},
+				"page": {
+					"type": "integer",
+					"description": "Page number of results to fetch for list operations"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of results per page for list operations (max 100)"
 				}
 			},
 			"required": ["operation", "owner", "repo"]
 		}`),
 		Handler: g.handlePullRequestsOperation,
-	}
+	})
 }
 
 func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
@@ -76,57 +116,119 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 	}).Info("handling pull requests operation")
 
 	var input struct {
-		Operation     string `json:"operation"`
-		Owner         string `json:"owner"`
-		Repo          string `json:"repo"`
-		Number        int    `json:"number"`
-		Title         string `json:"title"`
-		Body          string `json:"body"`
-		Head          string `json:"head"`
-		Base          string `json:"base"`
-		ReviewComment string `json:"review_comment"`
-		ReviewEvent   string `json:"review_event"`
+		Operation     string   `json:"operation"`
+		Owner         string   `json:"owner"`
+		Repo          string   `json:"repo"`
+		Number        int      `json:"number"`
+		Title         string   `json:"title"`
+		Body          string   `json:"body"`
+		Head          string   `json:"head"`
+		Base          string   `json:"base"`
+		ReviewComment string   `json:"review_comment"`
+		ReviewEvent   string   `json:"review_event"`
+		Draft         bool     `json:"draft"`
+		Reviewers     []string `json:"reviewers"`
+		TeamReviewers []string `json:"team_reviewers"`
+		ReviewID      int64    `json:"review_id"`
+		Message       string   `json:"message"`
+		MergeMethod   string   `json:"merge_method"`
+		CommitTitle   string   `json:"commit_title"`
+		Page          int      `json:"page"`
+		PerPage       int      `json:"per_page"`
 	}
 
 	if err := json.Unmarshal(params.Arguments, &input); err != nil {
-		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+		return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+	}
+
+	if input.Operation == "merge" && !isValidMergeMethod(input.MergeMethod) {
+		return returnErrorOutput(validationErrorf("invalid merge_method: %s (allowed: merge, squash, rebase)", input.MergeMethod)), nil
+	}
+
+	if input.Operation == "request_reviewers" && len(input.Reviewers) == 0 && len(input.TeamReviewers) == 0 {
+		return returnErrorOutput(validationErrorf("reviewers or team_reviewers is required for request_reviewers operation")), nil
+	}
+
+	if input.Operation == "dismiss_review" && (input.ReviewID == 0 || input.Message == "") {
+		return returnErrorOutput(validationErrorf("review_id and message are required for dismiss_review operation")), nil
 	}
 
 	var result interface{}
-	var err error
-
-	switch input.Operation {
-	case "create":
-		result, _, err = g.client.PullRequests.Create(ctx, input.Owner, input.Repo, &github.NewPullRequest{
-			Title: &input.Title,
-			Body:  &input.Body,
-			Head:  &input.Head,
-			Base:  &input.Base,
-		})
-	case "get":
-		result, _, err = g.client.PullRequests.Get(ctx, input.Owner, input.Repo, input.Number)
-	case "list":
-		result, _, err = g.client.PullRequests.List(ctx, input.Owner, input.Repo, &github.PullRequestListOptions{})
-	case "update":
-		result, _, err = g.client.PullRequests.Edit(ctx, input.Owner, input.Repo, input.Number, &github.PullRequest{
-			Title: &input.Title,
-			Body:  &input.Body,
-		})
-	case "merge":
-		result, _, err = g.client.PullRequests.Merge(ctx, input.Owner, input.Repo, input.Number, input.Body, &github.PullRequestOptions{})
-	case "review":
-		result, _, err = g.client.PullRequests.CreateReview(ctx, input.Owner, input.Repo, input.Number, &github.PullRequestReviewRequest{
-			Body:  &input.ReviewComment,
-			Event: &input.ReviewEvent,
-		})
-	case "list_files":
-		result, _, err = g.client.PullRequests.ListFiles(ctx, input.Owner, input.Repo, input.Number, &github.ListOptions{})
-	default:
-		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	var resp *github.Response
+
+	err := g.retryOnRateLimit(ctx, func() error {
+		var err error
+		switch input.Operation {
+		case "create":
+			result, _, err = g.client.PullRequests.Create(ctx, input.Owner, input.Repo, &github.NewPullRequest{
+				Title: &input.Title,
+				Body:  &input.Body,
+				Head:  &input.Head,
+				Base:  &input.Base,
+				Draft: &input.Draft,
+			})
+		case "get":
+			result, _, err = g.client.PullRequests.Get(ctx, input.Owner, input.Repo, input.Number)
+		case "list":
+			result, resp, err = g.client.PullRequests.List(ctx, input.Owner, input.Repo, &github.PullRequestListOptions{
+				ListOptions: github.ListOptions{Page: input.Page, PerPage: input.PerPage},
+			})
+		case "update":
+			result, _, err = g.client.PullRequests.Edit(ctx, input.Owner, input.Repo, input.Number, &github.PullRequest{
+				Title: &input.Title,
+				Body:  &input.Body,
+			})
+		case "merge":
+			result, _, err = g.client.PullRequests.Merge(ctx, input.Owner, input.Repo, input.Number, input.Body, &github.PullRequestOptions{
+				MergeMethod: input.MergeMethod,
+				CommitTitle: input.CommitTitle,
+			})
+		case "review":
+			result, _, err = g.client.PullRequests.CreateReview(ctx, input.Owner, input.Repo, input.Number, &github.PullRequestReviewRequest{
+				Body:  &input.ReviewComment,
+				Event: &input.ReviewEvent,
+			})
+		case "request_reviewers":
+			result, _, err = g.client.PullRequests.RequestReviewers(ctx, input.Owner, input.Repo, input.Number, github.ReviewersRequest{
+				Reviewers:     input.Reviewers,
+				TeamReviewers: input.TeamReviewers,
+			})
+		case "dismiss_review":
+			result, _, err = g.client.PullRequests.DismissReview(ctx, input.Owner, input.Repo, input.Number, input.ReviewID, &github.PullRequestReviewDismissalRequest{
+				Message: &input.Message,
+			})
+		case "list_files":
+			result, resp, err = g.client.PullRequests.ListFiles(ctx, input.Owner, input.Repo, input.Number, &github.ListOptions{
+				Page:    input.Page,
+				PerPage: input.PerPage,
+			})
+		default:
+			return errUnsupportedOperation
+		}
+		return err
+	})
+	if errors.Is(err, errUnsupportedOperation) {
+		return returnErrorOutput(validationErrorf("unsupported operation: %s", input.Operation)), nil
 	}
 
 	if err != nil {
-		return returnErrorOutput(fmt.Errorf("github pull request %s error: %w", input.Operation, err)), nil
+		return returnErrorOutput(githubToolError(fmt.Errorf("github pull request %s error: %w", input.Operation, err))), nil
+	}
+
+	// Reviewers are requested separately so a rate limit retry never recreates the pull request.
+	// The reviewer request responds with the updated pull request, including requested reviewers.
+	if input.Operation == "create" && len(input.Reviewers) > 0 {
+		pr := result.(*github.PullRequest)
+		err = g.retryOnRateLimit(ctx, func() error {
+			var err error
+			result, _, err = g.client.PullRequests.RequestReviewers(ctx, input.Owner, input.Repo, pr.GetNumber(), github.ReviewersRequest{
+				Reviewers: input.Reviewers,
+			})
+			return err
+		})
+		if err != nil {
+			return returnErrorOutput(githubToolError(fmt.Errorf("github pull request #%d created but requesting reviewers failed: %w", pr.GetNumber(), err))), nil
+		}
 	}
 
 	m := mustMarshal(result)
@@ -137,10 +239,16 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 		"result_length": len(m),
 	}).Info("GitHub pull request operation completed successfully")
 
-	return goai.CallToolResult{
-		Content: []goai.ToolResultContent{{
-			Type: "json",
-			Text: m,
-		}},
-	}, nil
+	return paginatedResult(m, resp), nil
+}
+
+// isValidMergeMethod checks the merge method against the methods supported by GitHub.
+// An empty method is valid and falls back to the repository default.
+func isValidMergeMethod(method string) bool {
+	switch method {
+	case "", "merge", "squash", "rebase":
+		return true
+	default:
+		return false
+	}
 }
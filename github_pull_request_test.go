@@ -53,6 +53,8 @@ func TestGetPullRequestsTool(t *testing.T) {
 	assert.Contains(t, enum, "update")
 	assert.Contains(t, enum, "merge")
 	assert.Contains(t, enum, "review")
+	assert.Contains(t, enum, "request_reviewers")
+	assert.Contains(t, enum, "dismiss_review")
 	assert.Contains(t, enum, "list_files")
 }
 
@@ -361,3 +363,361 @@ func TestHandlePullRequestsOperation_ListFiles(t *testing.T) {
 	assert.Equal(t, "file1.go", *files[0].Filename)
 	assert.Equal(t, "modified", *files[0].Status)
 }
+
+func TestHandlePullRequestsOperation_ListPagination(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub pull request operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "3", r.URL.Query().Get("page"))
+		assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+
+		prs := []*github.PullRequest{{Number: github.Int(21)}}
+		err := json.NewEncoder(w).Encode(prs)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"page":      3,
+		"per_page":  10,
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+
+	var prs []*github.PullRequest
+	err = json.Unmarshal([]byte(result.Content[0].Text), &prs)
+	require.NoError(t, err)
+	assert.Len(t, prs, 1)
+}
+
+func TestHandlePullRequestsOperation_MergeSquash(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub pull request operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "squash", body["merge_method"])
+		assert.Equal(t, "Squashed feature", body["commit_title"])
+
+		result := &github.PullRequestMergeResult{Merged: github.Bool(true)}
+		err = json.NewEncoder(w).Encode(result)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation":    "merge",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"number":       1,
+		"merge_method": "squash",
+		"commit_title": "Squashed feature",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestHandlePullRequestsOperation_MergeInvalidMethod(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	input := map[string]interface{}{
+		"operation":    "merge",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"number":       1,
+		"merge_method": "fast-forward",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "invalid merge_method")
+}
+
+func TestHandlePullRequestsOperation_CreateDraftWithReviewers(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub pull request operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var prReq github.NewPullRequest
+		err := json.NewDecoder(r.Body).Decode(&prReq)
+		assert.NoError(t, err)
+		assert.True(t, prReq.GetDraft())
+
+		pr := &github.PullRequest{
+			Number: github.Int(7),
+			Draft:  github.Bool(true),
+		}
+		err = json.NewEncoder(w).Encode(pr)
+		assert.NoError(t, err)
+	})
+
+	reviewersRequested := false
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/7/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		reviewersRequested = true
+
+		var reviewers github.ReviewersRequest
+		err := json.NewDecoder(r.Body).Decode(&reviewers)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, reviewers.Reviewers)
+
+		pr := &github.PullRequest{
+			Number: github.Int(7),
+			Draft:  github.Bool(true),
+			RequestedReviewers: []*github.User{
+				{Login: github.String("alice")},
+				{Login: github.String("bob")},
+			},
+		}
+		err = json.NewEncoder(w).Encode(pr)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"title":     "Draft PR",
+		"head":      "feature-branch",
+		"base":      "main",
+		"draft":     true,
+		"reviewers": []string{"alice", "bob"},
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.True(t, reviewersRequested)
+
+	var responsePR github.PullRequest
+	err = json.Unmarshal([]byte(result.Content[0].Text), &responsePR)
+	require.NoError(t, err)
+	assert.True(t, responsePR.GetDraft())
+	assert.Len(t, responsePR.RequestedReviewers, 2)
+}
+
+func TestHandlePullRequestsOperation_RequestReviewers(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub pull request operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	reviewersRequested := false
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/3/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		reviewersRequested = true
+
+		var reviewers github.ReviewersRequest
+		err := json.NewDecoder(r.Body).Decode(&reviewers)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, reviewers.Reviewers)
+		assert.Equal(t, []string{"platform"}, reviewers.TeamReviewers)
+
+		pr := &github.PullRequest{
+			Number:             github.Int(3),
+			RequestedReviewers: []*github.User{{Login: github.String("alice")}},
+			RequestedTeams:     []*github.Team{{Slug: github.String("platform")}},
+		}
+		err = json.NewEncoder(w).Encode(pr)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation":      "request_reviewers",
+		"owner":          "test-owner",
+		"repo":           "test-repo",
+		"number":         3,
+		"reviewers":      []string{"alice"},
+		"team_reviewers": []string{"platform"},
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.True(t, reviewersRequested)
+
+	var responsePR github.PullRequest
+	err = json.Unmarshal([]byte(result.Content[0].Text), &responsePR)
+	require.NoError(t, err)
+	require.Len(t, responsePR.RequestedReviewers, 1)
+	assert.Equal(t, "alice", responsePR.RequestedReviewers[0].GetLogin())
+	require.Len(t, responsePR.RequestedTeams, 1)
+	assert.Equal(t, "platform", responsePR.RequestedTeams[0].GetSlug())
+}
+
+func TestHandlePullRequestsOperation_RequestReviewersRequiresReviewers(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	input := map[string]interface{}{
+		"operation": "request_reviewers",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    3,
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "reviewers or team_reviewers is required")
+}
+
+func TestHandlePullRequestsOperation_DismissReview(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling pull requests operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub pull request operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	dismissed := false
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/3/reviews/42/dismissals", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		dismissed = true
+
+		var dismissal github.PullRequestReviewDismissalRequest
+		err := json.NewDecoder(r.Body).Decode(&dismissal)
+		assert.NoError(t, err)
+		assert.Equal(t, "Outdated after force push", dismissal.GetMessage())
+
+		review := &github.PullRequestReview{
+			ID:    github.Int64(42),
+			State: github.String("DISMISSED"),
+		}
+		err = json.NewEncoder(w).Encode(review)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "dismiss_review",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    3,
+		"review_id": 42,
+		"message":   "Outdated after force push",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handlePullRequestsOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubPullRequestsToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.True(t, dismissed)
+
+	var review github.PullRequestReview
+	err = json.Unmarshal([]byte(result.Content[0].Text), &review)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), review.GetID())
+	assert.Equal(t, "DISMISSED", review.GetState())
+}
@@ -0,0 +1,77 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// rateLimitStatus is the quota of a single GitHub API rate limit category
+type rateLimitStatus struct {
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Reset     string `json:"reset"`
+}
+
+// GetRateLimitTool returns a tool that reports the remaining GitHub API quota
+func (g *GitHub) GetRateLimitTool() goai.Tool {
+	return withInputValidation(g.logger, goai.Tool{
+		Name:        GitHubRateLimitToolName,
+		Description: "Reports the GitHub API rate limits for core and search requests, how many requests remain and when the limits reset",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {}
+		}`),
+		Handler: g.handleRateLimitOperation,
+	})
+}
+
+func (g *GitHub) handleRateLimitOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool_name": params.Name,
+	}).Info("handling rate limit operation")
+
+	// Fetching the rate limits does not count against them, so no retry is needed
+	limits, _, err := g.client.RateLimits(ctx)
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+		}).Error("GitHub rate limit operation failed")
+
+		span.RecordError(err)
+		return returnErrorOutput(githubToolError(err)), nil
+	}
+
+	result := map[string]rateLimitStatus{
+		"core":   newRateLimitStatus(limits.GetCore()),
+		"search": newRateLimitStatus(limits.GetSearch()),
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: mustMarshal(result),
+		}},
+	}, nil
+}
+
+// newRateLimitStatus converts a GitHub rate into its JSON form with an RFC3339 reset time
+func newRateLimitStatus(rate *github.Rate) rateLimitStatus {
+	if rate == nil {
+		return rateLimitStatus{}
+	}
+
+	return rateLimitStatus{
+		Limit:     rate.Limit,
+		Remaining: rate.Remaining,
+		Reset:     rate.Reset.UTC().Format(time.RFC3339),
+	}
+}
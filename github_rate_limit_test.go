@@ -0,0 +1,46 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRateLimitOperation(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling rate limit operation"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"resources":{
+			"core":{"limit":5000,"remaining":4321,"reset":1700000000},
+			"search":{"limit":30,"remaining":12,"reset":1700000060}
+		}}`)
+	})
+
+	result, err := gh.handleRateLimitOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRateLimitToolName,
+		Arguments: []byte(`{}`),
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.JSONEq(t, `{
+		"core":{"limit":5000,"remaining":4321,"reset":"2023-11-14T22:13:20Z"},
+		"search":{"limit":30,"remaining":12,"reset":"2023-11-14T22:14:20Z"}
+	}`, result.Content[0].Text)
+}
@@ -3,7 +3,10 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/shaharia-lab/goai"
@@ -11,15 +14,15 @@ import (
 
 // GetRepositoryTool returns a tool for managing GitHub repositories
 func (g *GitHub) GetRepositoryTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GitHubRepositoryToolName,
-		Description: "Manages GitHub repositories - create, delete, update, fork",
+		Description: "Manages GitHub repositories - get, create, delete, update, fork, branches, file contents, commit history and stars",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["create", "delete", "update", "fork", "list_branches", "create_branch", "protect_branch"],
+					"enum": ["get", "create", "delete", "update", "fork", "list_branches", "create_branch", "protect_branch", "get_contents", "update_contents", "star", "unstar", "list_stargazers", "list_commits", "compare"],
 					"description": "Repository operation to perform"
 				},
 				"owner": {
@@ -45,12 +48,79 @@ func (g *GitHub) GetRepositoryTool() goai.Tool {
 				"source_branch": {
 					"type": "string",
 					"description": "Source branch for new branch creation"
+				},
+				"path": {
+					"type": "string",
+					"description": "File or directory path within the repository (for get_contents, update_contents and list_commits)"
+				},
+				"ref": {
+					"type": "string",
+					"description": "Branch, tag or commit SHA to read from (for get_contents). Defaults to the default branch"
+				},
+				"content": {
+					"type": "string",
+					"description": "New file content (for update_contents)"
+				},
+				"message": {
+					"type": "string",
+					"description": "Commit message (for update_contents)"
+				},
+				"sha": {
+					"type": "string",
+					"description": "Blob SHA of the file being replaced (for update_contents), or the branch or commit SHA to start listing from (for list_commits)"
+				},
+				"since": {
+					"type": "string",
+					"description": "Only commits after this RFC3339 timestamp (for list_commits)"
+				},
+				"until": {
+					"type": "string",
+					"description": "Only commits before this RFC3339 timestamp (for list_commits)"
+				},
+				"base": {
+					"type": "string",
+					"description": "Base branch, tag or commit SHA (for compare)"
+				},
+				"head": {
+					"type": "string",
+					"description": "Head branch, tag or commit SHA (for compare)"
+				},
+				"required_approving_review_count": {
+					"type": "integer",
+					"description": "Number of approving reviews required before merging, 1-6 (for protect_branch). Defaults to 1"
+				},
+				"required_status_checks": {
+					"type": "array",
+					"items": {
+						"type": "string"
+					},
+					"description": "Status check contexts that must pass before merging (for protect_branch)"
+				},
+				"strict_status_checks": {
+					"type": "boolean",
+					"description": "Require branches to be up to date before merging (for protect_branch). Defaults to true"
+				},
+				"enforce_admins": {
+					"type": "boolean",
+					"description": "Apply the protection rules to repository administrators too (for protect_branch)"
+				},
+				"dismiss_stale_reviews": {
+					"type": "boolean",
+					"description": "Dismiss approving reviews when new commits are pushed (for protect_branch)"
+				},
+				"page": {
+					"type": "integer",
+					"description": "Page number of results to fetch for list operations"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of results per page for list operations (max 100)"
 				}
 			},
 			"required": ["operation"]
 		}`),
 		Handler: g.handleRepositoryOperation,
-	}
+	})
 }
 
 func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
@@ -65,6 +135,18 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 		Private      bool   `json:"private"`
 		Branch       string `json:"branch"`
 		SourceBranch string `json:"source_branch"`
+		Path         string `json:"path"`
+		Ref          string `json:"ref"`
+		Content      string `json:"content"`
+		Message      string `json:"message"`
+		SHA          string `json:"sha"`
+		Since        string `json:"since"`
+		Until        string `json:"until"`
+		Base         string `json:"base"`
+		Head         string `json:"head"`
+		Page         int    `json:"page"`
+		PerPage      int    `json:"per_page"`
+		branchProtectionInput
 	}
 
 	g.logger.WithFields(map[string]interface{}{
@@ -73,57 +155,109 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 	}).Info("handling repository operation")
 
 	if err := json.Unmarshal(params.Arguments, &input); err != nil {
-		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+		return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+	}
+
+	since, err := parseOptionalTime("since", input.Since)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	until, err := parseOptionalTime("until", input.Until)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	if input.Operation == "compare" && (input.Base == "" || input.Head == "") {
+		return returnErrorOutput(validationErrorf("base and head are required for compare")), nil
+	}
+	if input.Operation == "update_contents" && input.Path == "" {
+		return returnErrorOutput(validationErrorf("path is required for update_contents")), nil
+	}
+	if input.Operation == "update_contents" && input.Message == "" {
+		return returnErrorOutput(validationErrorf("message is required for update_contents")), nil
 	}
 
 	var result interface{}
-	var err error
-
-	switch input.Operation {
-	case "create":
-		result, _, err = g.client.Repositories.Create(ctx, "", &github.Repository{
-			Name:        &input.Repo,
-			Description: &input.Description,
-			Private:     &input.Private,
-		})
-	case "delete":
-		_, err = g.client.Repositories.Delete(ctx, input.Owner, input.Repo)
-		if err == nil {
-			result = map[string]string{"status": "deleted"}
-		}
-	case "update":
-		result, _, err = g.client.Repositories.Edit(ctx, input.Owner, input.Repo, &github.Repository{
-			Description: &input.Description,
-			Private:     &input.Private,
-		})
-	case "fork":
-		result, _, err = g.client.Repositories.CreateFork(ctx, input.Owner, input.Repo, &github.RepositoryCreateForkOptions{})
-	case "list_branches":
-		result, _, err = g.client.Repositories.ListBranches(ctx, input.Owner, input.Repo, &github.BranchListOptions{})
-	case "create_branch":
-		// Get the source branch's SHA
-		ref, _, err := g.client.Git.GetRef(ctx, input.Owner, input.Repo, "refs/heads/"+input.SourceBranch)
-		if err != nil {
-			return goai.CallToolResult{}, err
-		}
-		result, _, _ = g.client.Git.CreateRef(ctx, input.Owner, input.Repo, &github.Reference{
-			Ref: github.String("refs/heads/" + input.Branch),
-			Object: &github.GitObject{
-				SHA: ref.Object.SHA,
-			},
-		})
-	case "protect_branch":
-		result, _, err = g.client.Repositories.UpdateBranchProtection(ctx, input.Owner, input.Repo, input.Branch,
-			&github.ProtectionRequest{
-				RequiredStatusChecks: &github.RequiredStatusChecks{
-					Strict: true,
-				},
-				RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
-					RequiredApprovingReviewCount: 1,
+	var resp *github.Response
+
+	err = g.retryOnRateLimit(ctx, func() error {
+		var err error
+		switch input.Operation {
+		case "get":
+			result, _, err = g.client.Repositories.Get(ctx, input.Owner, input.Repo)
+		case "create":
+			result, _, err = g.client.Repositories.Create(ctx, "", &github.Repository{
+				Name:        &input.Repo,
+				Description: &input.Description,
+				Private:     &input.Private,
+			})
+		case "delete":
+			_, err = g.client.Repositories.Delete(ctx, input.Owner, input.Repo)
+			if err == nil {
+				result = map[string]string{"status": "deleted"}
+			}
+		case "update":
+			result, _, err = g.client.Repositories.Edit(ctx, input.Owner, input.Repo, &github.Repository{
+				Description: &input.Description,
+				Private:     &input.Private,
+			})
+		case "fork":
+			result, _, err = g.client.Repositories.CreateFork(ctx, input.Owner, input.Repo, &github.RepositoryCreateForkOptions{})
+		case "list_branches":
+			result, resp, err = g.client.Repositories.ListBranches(ctx, input.Owner, input.Repo, &github.BranchListOptions{
+				ListOptions: github.ListOptions{Page: input.Page, PerPage: input.PerPage},
+			})
+		case "create_branch":
+			// Get the source branch's SHA
+			var ref *github.Reference
+			ref, _, err = g.client.Git.GetRef(ctx, input.Owner, input.Repo, "refs/heads/"+input.SourceBranch)
+			if err != nil {
+				break
+			}
+			result, _, err = g.client.Git.CreateRef(ctx, input.Owner, input.Repo, &github.Reference{
+				Ref: github.String("refs/heads/" + input.Branch),
+				Object: &github.GitObject{
+					SHA: ref.Object.SHA,
 				},
 			})
-	default:
-		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+		case "protect_branch":
+			result, _, err = g.client.Repositories.UpdateBranchProtection(ctx, input.Owner, input.Repo, input.Branch,
+				input.protectionRequest())
+		case "get_contents":
+			result, err = g.getContents(ctx, input.Owner, input.Repo, input.Path, input.Ref)
+		case "update_contents":
+			result, err = g.updateContents(ctx, input.Owner, input.Repo, input.Path, input.Content, input.Message, input.Branch, input.SHA)
+		case "star":
+			_, err = g.client.Activity.Star(ctx, input.Owner, input.Repo)
+			if err == nil {
+				result = map[string]string{"status": "starred"}
+			}
+		case "unstar":
+			_, err = g.client.Activity.Unstar(ctx, input.Owner, input.Repo)
+			if err == nil {
+				result = map[string]string{"status": "unstarred"}
+			}
+		case "list_stargazers":
+			result, resp, err = g.client.Activity.ListStargazers(ctx, input.Owner, input.Repo, &github.ListOptions{
+				Page:    input.Page,
+				PerPage: input.PerPage,
+			})
+		case "list_commits":
+			result, resp, err = g.client.Repositories.ListCommits(ctx, input.Owner, input.Repo, &github.CommitsListOptions{
+				SHA:         input.SHA,
+				Path:        input.Path,
+				Since:       since,
+				Until:       until,
+				ListOptions: github.ListOptions{Page: input.Page, PerPage: input.PerPage},
+			})
+		case "compare":
+			result, _, err = g.client.Repositories.CompareCommits(ctx, input.Owner, input.Repo, input.Base, input.Head, nil)
+		default:
+			return errUnsupportedOperation
+		}
+		return err
+	})
+	if errors.Is(err, errUnsupportedOperation) {
+		return returnErrorOutput(validationErrorf("unsupported operation: %s", input.Operation)), nil
 	}
 
 	if err != nil {
@@ -133,7 +267,7 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 			"operation":                 input.Operation,
 		}).Error("GitHub repository operation failed")
 
-		return returnErrorOutput(fmt.Errorf("github repository %s error: %w", input.Operation, err)), nil
+		return returnErrorOutput(githubToolError(fmt.Errorf("github repository %s error: %w", input.Operation, err))), nil
 	}
 
 	m := mustMarshal(result)
@@ -143,10 +277,155 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 		"result_length": len(m),
 	}).Info("GitHub repository operation completed successfully")
 
-	return goai.CallToolResult{
-		Content: []goai.ToolResultContent{{
-			Type: "json",
-			Text: m,
-		}},
-	}, nil
+	return paginatedResult(m, resp), nil
+}
+
+// branchProtectionInput holds the protect_branch settings. Unset fields fall back
+// to requiring one approving review and up-to-date branches.
+type branchProtectionInput struct {
+	RequiredApprovingReviewCount *int     `json:"required_approving_review_count"`
+	RequiredStatusChecks         []string `json:"required_status_checks"`
+	StrictStatusChecks           *bool    `json:"strict_status_checks"`
+	EnforceAdmins                bool     `json:"enforce_admins"`
+	DismissStaleReviews          bool     `json:"dismiss_stale_reviews"`
+}
+
+// protectionRequest maps the input onto a GitHub branch protection request
+func (b branchProtectionInput) protectionRequest() *github.ProtectionRequest {
+	reviewCount := 1
+	if b.RequiredApprovingReviewCount != nil {
+		reviewCount = *b.RequiredApprovingReviewCount
+	}
+
+	strict := true
+	if b.StrictStatusChecks != nil {
+		strict = *b.StrictStatusChecks
+	}
+
+	statusChecks := &github.RequiredStatusChecks{Strict: strict}
+	if len(b.RequiredStatusChecks) > 0 {
+		contexts := b.RequiredStatusChecks
+		statusChecks.Contexts = &contexts
+	}
+
+	return &github.ProtectionRequest{
+		RequiredStatusChecks: statusChecks,
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: reviewCount,
+			DismissStaleReviews:          b.DismissStaleReviews,
+		},
+		EnforceAdmins: b.EnforceAdmins,
+	}
+}
+
+// parseOptionalTime parses an RFC3339 timestamp input, returning the zero time
+// when the value is empty
+func parseOptionalTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, validationErrorf("invalid %s value %q, expected RFC3339 format: %w", name, value, err)
+	}
+	return parsed, nil
+}
+
+// repositoryContent is the simplified representation of a file or directory entry
+// returned by the get_contents operation
+type repositoryContent struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+	Size    int    `json:"size"`
+	SHA     string `json:"sha"`
+	Content string `json:"content,omitempty"`
+}
+
+// getContents fetches a file or directory from a repository. File content is
+// returned decoded, while directories are returned as a listing of their entries.
+func (g *GitHub) getContents(ctx context.Context, owner, repo, path, ref string) (interface{}, error) {
+	var opts *github.RepositoryContentGetOptions
+	if ref != "" {
+		opts = &github.RepositoryContentGetOptions{Ref: ref}
+	}
+
+	file, dir, _, err := g.client.Repositories.GetContents(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if file != nil {
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode file content: %w", err)
+		}
+
+		return repositoryContent{
+			Name:    file.GetName(),
+			Path:    file.GetPath(),
+			Type:    file.GetType(),
+			Size:    file.GetSize(),
+			SHA:     file.GetSHA(),
+			Content: content,
+		}, nil
+	}
+
+	entries := make([]repositoryContent, 0, len(dir))
+	for _, entry := range dir {
+		entries = append(entries, repositoryContent{
+			Name: entry.GetName(),
+			Path: entry.GetPath(),
+			Type: entry.GetType(),
+			Size: entry.GetSize(),
+			SHA:  entry.GetSHA(),
+		})
+	}
+
+	return entries, nil
+}
+
+// updateContents commits a file to the repository. When no SHA is provided the file
+// is created, falling back to an update of the existing file if it already exists.
+func (g *GitHub) updateContents(ctx context.Context, owner, repo, path, content, message, branch, sha string) (interface{}, error) {
+	opts := &github.RepositoryContentFileOptions{
+		Message: github.String(message),
+		Content: []byte(content),
+	}
+	if branch != "" {
+		opts.Branch = github.String(branch)
+	}
+
+	if sha != "" {
+		opts.SHA = github.String(sha)
+		result, _, err := g.client.Repositories.UpdateFile(ctx, owner, repo, path, opts)
+		return result, err
+	}
+
+	result, _, err := g.client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+	if err == nil {
+		return result, nil
+	}
+
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil || errResp.Response.StatusCode != http.StatusUnprocessableEntity {
+		return nil, err
+	}
+
+	// The file already exists, so look up its current SHA and update it instead
+	var getOpts *github.RepositoryContentGetOptions
+	if branch != "" {
+		getOpts = &github.RepositoryContentGetOptions{Ref: branch}
+	}
+	existing, _, _, err := g.client.Repositories.GetContents(ctx, owner, repo, path, getOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing file SHA: %w", err)
+	}
+	if existing == nil {
+		return nil, validationErrorf("path %s is not a file", path)
+	}
+
+	opts.SHA = existing.SHA
+	result, _, err = g.client.Repositories.UpdateFile(ctx, owner, repo, path, opts)
+	return result, err
 }
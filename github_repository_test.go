@@ -51,6 +51,55 @@ func TestGetRepositoryTool(t *testing.T) {
 	}
 }
 
+func TestHandleRepositoryOperation_Get(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		err := json.NewEncoder(w).Encode(&github.Repository{
+			Name:            github.String("test-repo"),
+			FullName:        github.String("test-owner/test-repo"),
+			DefaultBranch:   github.String("main"),
+			StargazersCount: github.Int(42),
+			Visibility:      github.String("public"),
+		})
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "get",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var repo github.Repository
+	err = json.Unmarshal([]byte(result.Content[0].Text), &repo)
+	require.NoError(t, err)
+	assert.Equal(t, "main", repo.GetDefaultBranch())
+	assert.Equal(t, 42, repo.GetStargazersCount())
+	assert.Equal(t, "public", repo.GetVisibility())
+}
+
 func TestHandleRepositoryOperation_Create(t *testing.T) {
 	// Create mock logger and set up expected calls
 	mockLogger := &MockLogger{}
@@ -459,3 +508,619 @@ func TestHandleRepositoryOperation_ProtectBranch(t *testing.T) {
 	assert.True(t, protection.RequiredStatusChecks.Strict)
 	assert.Equal(t, 1, protection.RequiredPullRequestReviews.RequiredApprovingReviewCount)
 }
+
+func TestHandleRepositoryOperation_ListBranchesPagination(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		w.Header().Set("Link", `<https://api.github.com/repos/test-owner/test-repo/branches?page=2&per_page=100>; rel="next"`)
+		branches := []*github.Branch{{Name: github.String("main")}}
+		err := json.NewEncoder(w).Encode(branches)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "list_branches",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"page":      1,
+		"per_page":  100,
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "next_page: 2", result.Content[1].Text)
+}
+
+func TestHandleRepositoryOperation_CreateBranchFailure(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Error", []interface{}{"GitHub repository operation failed"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/git/ref/heads/main", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		ref := &github.Reference{
+			Ref: github.String("refs/heads/main"),
+			Object: &github.GitObject{
+				SHA: github.String("abc123"),
+			},
+		}
+		err := json.NewEncoder(w).Encode(ref)
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/repos/test-owner/test-repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, err := w.Write([]byte(`{"message": "Reference already exists"}`))
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation":     "create_branch",
+		"owner":         "test-owner",
+		"repo":          "test-repo",
+		"branch":        "feature",
+		"source_branch": "main",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	require.NotEmpty(t, result.Content)
+	assert.Contains(t, result.Content[0].Text, "Reference already exists")
+	mockLogger.AssertExpectations(t)
+}
+
+func TestHandleRepositoryOperation_GetContentsFile(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "develop", r.URL.Query().Get("ref"))
+
+		content := &github.RepositoryContent{
+			Type:     github.String("file"),
+			Name:     github.String("README.md"),
+			Path:     github.String("README.md"),
+			SHA:      github.String("abc123"),
+			Size:     github.Int(11),
+			Encoding: github.String("base64"),
+			Content:  github.String("SGVsbG8gV29ybGQ="),
+		}
+		err := json.NewEncoder(w).Encode(content)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "get_contents",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "README.md",
+		"ref":       "develop",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var content repositoryContent
+	err = json.Unmarshal([]byte(result.Content[0].Text), &content)
+	require.NoError(t, err)
+	assert.Equal(t, "file", content.Type)
+	assert.Equal(t, "abc123", content.SHA)
+	assert.Equal(t, "Hello World", content.Content)
+}
+
+func TestHandleRepositoryOperation_GetContentsDirectory(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/docs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		entries := []*github.RepositoryContent{
+			{Type: github.String("file"), Name: github.String("index.md"), Path: github.String("docs/index.md")},
+			{Type: github.String("dir"), Name: github.String("images"), Path: github.String("docs/images")},
+		}
+		err := json.NewEncoder(w).Encode(entries)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "get_contents",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "docs",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var entries []repositoryContent
+	err = json.Unmarshal([]byte(result.Content[0].Text), &entries)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "docs/index.md", entries[0].Path)
+	assert.Equal(t, "dir", entries[1].Type)
+	assert.Empty(t, entries[1].Content)
+}
+
+func TestHandleRepositoryOperation_UpdateContentsCreate(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/docs/new.md", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+
+		var opts github.RepositoryContentFileOptions
+		err := json.NewDecoder(r.Body).Decode(&opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "Add new docs", opts.GetMessage())
+		assert.Equal(t, "feature", opts.GetBranch())
+		assert.Equal(t, "# New", string(opts.Content))
+		assert.Nil(t, opts.SHA)
+
+		w.WriteHeader(http.StatusCreated)
+		response := &github.RepositoryContentResponse{
+			Content: &github.RepositoryContent{Path: github.String("docs/new.md")},
+			Commit: github.Commit{
+				SHA:     github.String("def456"),
+				Message: github.String("Add new docs"),
+			},
+		}
+		err = json.NewEncoder(w).Encode(response)
+		assert.NoError(t, err)
+	})
+
+	input := map[string]interface{}{
+		"operation": "update_contents",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "docs/new.md",
+		"content":   "# New",
+		"message":   "Add new docs",
+		"branch":    "feature",
+	}
+
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response github.RepositoryContentResponse
+	err = json.Unmarshal([]byte(result.Content[0].Text), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "def456", response.Commit.GetSHA())
+	assert.Equal(t, "Add new docs", response.Commit.GetMessage())
+}
+
+func TestHandleRepositoryOperation_UpdateContentsRequiresPathAndMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing path",
+			input:    map[string]interface{}{"content": "# New", "message": "Add new docs"},
+			expected: "path is required for update_contents",
+		},
+		{
+			name:     "missing message",
+			input:    map[string]interface{}{"path": "docs/new.md", "content": "# New"},
+			expected: "message is required for update_contents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := &MockLogger{}
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = mockLogger
+			defer cleanup()
+
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			})
+
+			tt.input["operation"] = "update_contents"
+			tt.input["owner"] = "test-owner"
+			tt.input["repo"] = "test-repo"
+			inputBytes, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+
+			result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+				Name:      GitHubRepositoryToolName,
+				Arguments: inputBytes,
+			})
+
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+		})
+	}
+}
+
+func TestHandleRepositoryOperation_StarAndUnstar(t *testing.T) {
+	tests := []struct {
+		operation      string
+		expectedMethod string
+		expectedStatus string
+	}{
+		{operation: "star", expectedMethod: "PUT", expectedStatus: "starred"},
+		{operation: "unstar", expectedMethod: "DELETE", expectedStatus: "unstarred"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			mockLogger := &MockLogger{}
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+			mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = mockLogger
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			called := false
+			mux.HandleFunc("/user/starred/test-owner/test-repo", func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				assert.Equal(t, tt.expectedMethod, r.Method)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			inputBytes, err := json.Marshal(map[string]interface{}{
+				"operation": tt.operation,
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+			})
+			require.NoError(t, err)
+
+			result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+				Name:      GitHubRepositoryToolName,
+				Arguments: inputBytes,
+			})
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.True(t, called)
+
+			var response map[string]string
+			err = json.Unmarshal([]byte(result.Content[0].Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, response["status"])
+		})
+	}
+}
+
+func TestHandleRepositoryOperation_ListStargazers(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/stargazers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+
+		w.Header().Set("Link", `<https://api.github.com/repos/test-owner/test-repo/stargazers?page=3>; rel="next"`)
+		stargazers := []*github.Stargazer{
+			{User: &github.User{Login: github.String("alice")}},
+			{User: &github.User{Login: github.String("bob")}},
+		}
+		err := json.NewEncoder(w).Encode(stargazers)
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "list_stargazers",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"page":      2,
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "next_page: 3", result.Content[1].Text)
+
+	var stargazers []*github.Stargazer
+	err = json.Unmarshal([]byte(result.Content[0].Text), &stargazers)
+	require.NoError(t, err)
+	require.Len(t, stargazers, 2)
+	assert.Equal(t, "alice", stargazers[0].GetUser().GetLogin())
+}
+
+func TestHandleRepositoryOperation_ListCommits(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		query := r.URL.Query()
+		assert.Equal(t, "main", query.Get("sha"))
+		assert.Equal(t, "README.md", query.Get("path"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", query.Get("since"))
+		assert.Equal(t, "2024-02-01T00:00:00Z", query.Get("until"))
+
+		commits := []*github.RepositoryCommit{
+			{SHA: github.String("abc123"), Commit: &github.Commit{Message: github.String("Second commit")}},
+			{SHA: github.String("def456"), Commit: &github.Commit{Message: github.String("First commit")}},
+		}
+		err := json.NewEncoder(w).Encode(commits)
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "list_commits",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"sha":       "main",
+		"path":      "README.md",
+		"since":     "2024-01-01T00:00:00Z",
+		"until":     "2024-02-01T00:00:00Z",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var commits []*github.RepositoryCommit
+	err = json.Unmarshal([]byte(result.Content[0].Text), &commits)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "abc123", commits[0].GetSHA())
+	assert.Equal(t, "First commit", commits[1].GetCommit().GetMessage())
+}
+
+func TestHandleRepositoryOperation_Compare(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/compare/main...feature", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		comparison := &github.CommitsComparison{
+			Status:   github.String("ahead"),
+			AheadBy:  github.Int(2),
+			BehindBy: github.Int(0),
+		}
+		err := json.NewEncoder(w).Encode(comparison)
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "compare",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"base":      "main",
+		"head":      "feature",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var comparison github.CommitsComparison
+	err = json.Unmarshal([]byte(result.Content[0].Text), &comparison)
+	require.NoError(t, err)
+	assert.Equal(t, "ahead", comparison.GetStatus())
+	assert.Equal(t, 2, comparison.GetAheadBy())
+}
+
+func TestHandleRepositoryOperation_CompareRequiresRefs(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation": "compare",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"base":      "main",
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "base and head are required for compare", result.Content[0].Text)
+}
+
+func TestHandleRepositoryOperation_ProtectBranchCustomSettings(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", []interface{}{"handling repository operation"}).Return()
+	mockLogger.On("Info", []interface{}{"GitHub repository operation completed successfully"}).Return()
+
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = mockLogger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+
+		var protection github.ProtectionRequest
+		err := json.NewDecoder(r.Body).Decode(&protection)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, protection.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+		assert.True(t, protection.RequiredPullRequestReviews.DismissStaleReviews)
+		assert.False(t, protection.RequiredStatusChecks.Strict)
+		assert.Equal(t, []string{"ci/build", "ci/test"}, *protection.RequiredStatusChecks.Contexts)
+		assert.True(t, protection.EnforceAdmins)
+
+		err = json.NewEncoder(w).Encode(&github.Protection{})
+		assert.NoError(t, err)
+	})
+
+	inputBytes, err := json.Marshal(map[string]interface{}{
+		"operation":                       "protect_branch",
+		"owner":                           "test-owner",
+		"repo":                            "test-repo",
+		"branch":                          "main",
+		"required_approving_review_count": 2,
+		"required_status_checks":          []string{"ci/build", "ci/test"},
+		"strict_status_checks":            false,
+		"enforce_admins":                  true,
+		"dismiss_stale_reviews":           true,
+	})
+	require.NoError(t, err)
+
+	result, err := gh.handleRepositoryOperation(context.Background(), goai.CallToolParams{
+		Name:      GitHubRepositoryToolName,
+		Arguments: inputBytes,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
@@ -3,6 +3,7 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/go-github/v60/github"
@@ -11,7 +12,7 @@ import (
 
 // GetSearchTool returns a tool for GitHub search operations
 func (g *GitHub) GetSearchTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GitHubSearchToolName,
 		Description: "Performs GitHub search operations across repositories, code, issues, and users",
 		InputSchema: json.RawMessage(`{
@@ -44,7 +45,7 @@ func (g *GitHub) GetSearchTool() goai.Tool {
 			"required": ["operation", "query"]
 		}`),
 		Handler: g.handleSearchOperation,
-	}
+	})
 }
 
 func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
@@ -65,11 +66,10 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 	}).Info("Received input")
 
 	if err := json.Unmarshal(params.Arguments, &input); err != nil {
-		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+		return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
 	}
 
 	var result interface{}
-	var err error
 
 	searchOpts := &github.SearchOptions{
 		Sort:  input.Sort,
@@ -90,17 +90,24 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 		input.Query = input.Query + " language:" + input.Language
 	}
 
-	switch input.Operation {
-	case "repositories":
-		result, _, err = g.client.Search.Repositories(ctx, input.Query, searchOpts)
-	case "code":
-		result, _, err = g.client.Search.Code(ctx, input.Query, searchOpts)
-	case "issues":
-		result, _, err = g.client.Search.Issues(ctx, input.Query, searchOpts)
-	case "users":
-		result, _, err = g.client.Search.Users(ctx, input.Query, searchOpts)
-	default:
-		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	err := g.retryOnRateLimit(ctx, func() error {
+		var err error
+		switch input.Operation {
+		case "repositories":
+			result, _, err = g.client.Search.Repositories(ctx, input.Query, searchOpts)
+		case "code":
+			result, _, err = g.client.Search.Code(ctx, input.Query, searchOpts)
+		case "issues":
+			result, _, err = g.client.Search.Issues(ctx, input.Query, searchOpts)
+		case "users":
+			result, _, err = g.client.Search.Users(ctx, input.Query, searchOpts)
+		default:
+			return errUnsupportedOperation
+		}
+		return err
+	})
+	if errors.Is(err, errUnsupportedOperation) {
+		return returnErrorOutput(validationErrorf("unsupported operation: %s", input.Operation)), nil
 	}
 
 	if err != nil {
@@ -109,7 +116,7 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 			"error":     err,
 		}).Error("GitHub search operation failed")
 
-		return returnErrorOutput(err), nil
+		return returnErrorOutput(githubToolError(err)), nil
 	}
 
 	m := mustMarshal(result)
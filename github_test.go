@@ -0,0 +1,169 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitHubTool_DefaultBaseURL(t *testing.T) {
+	gh := NewGitHubTool(&MockLogger{}, GitHubConfig{Token: "test-token"})
+
+	assert.Equal(t, "https://api.github.com/", gh.client.BaseURL.String())
+}
+
+func TestNewGitHubTool_EnterpriseBaseURL(t *testing.T) {
+	gh := NewGitHubTool(&MockLogger{}, GitHubConfig{
+		Token:   "test-token",
+		BaseURL: "https://github.example.com",
+	})
+
+	assert.Equal(t, "https://github.example.com/api/v3/", gh.client.BaseURL.String())
+	assert.Equal(t, "https://github.example.com/api/uploads/", gh.client.UploadURL.String())
+}
+
+func TestNewGitHubTool_InvalidEnterpriseURLFailsClosed(t *testing.T) {
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+	logger.On("Error", mock.Anything).Return()
+
+	gh := NewGitHubTool(logger, GitHubConfig{
+		Token:   "enterprise-token",
+		BaseURL: "://github.example.com",
+	})
+
+	// The client must not fall back to github.com with the Enterprise token
+	assert.IsType(t, failingTransport{}, gh.client.Client().Transport)
+
+	inputJSON, err := json.Marshal(map[string]interface{}{})
+	require.NoError(t, err)
+
+	result, err := gh.GetRateLimitTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      GitHubRateLimitToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "invalid GitHub Enterprise URLs")
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindInternal, kind)
+}
+
+func TestGitHub_RetryOnRateLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		writeLimitErr func(w http.ResponseWriter)
+	}{
+		{
+			name: "primary rate limit",
+			writeLimitErr: func(w http.ResponseWriter) {
+				w.Header().Set("X-RateLimit-Limit", "5000")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+			},
+		},
+		{
+			name: "secondary rate limit",
+			writeLimitErr: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := &MockLogger{}
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Warn", []interface{}{"GitHub rate limit hit, retrying after reset"}).Return()
+
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = mockLogger
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			calls := 0
+			mux.HandleFunc("/repos/test-owner/test-repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					tt.writeLimitErr(w)
+					return
+				}
+
+				err := json.NewEncoder(w).Encode(&github.Issue{Number: github.Int(1)})
+				assert.NoError(t, err)
+			})
+
+			input := map[string]interface{}{
+				"operation": "get",
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+				"number":    1,
+			}
+
+			inputBytes, err := json.Marshal(input)
+			require.NoError(t, err)
+
+			result, err := gh.handleIssuesOperation(context.Background(), goai.CallToolParams{
+				Name:      GitHubIssuesToolName,
+				Arguments: inputBytes,
+			})
+
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+			assert.Equal(t, 2, calls)
+			mockLogger.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGitHub_RetryOnRateLimitDisabled(t *testing.T) {
+	gh := &GitHub{
+		logger: &MockLogger{},
+		config: GitHubConfig{MaxRetries: -1},
+	}
+
+	calls := 0
+	err := gh.retryOnRateLimit(context.Background(), func() error {
+		calls++
+		return &github.RateLimitError{}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGitHub_RetryOnRateLimitGivesUpBeyondMaxRetryWait(t *testing.T) {
+	gh := &GitHub{
+		logger: &MockLogger{},
+		config: GitHubConfig{MaxRetryWait: 10 * time.Millisecond},
+	}
+
+	calls := 0
+	retryAfter := time.Hour
+	err := gh.retryOnRateLimit(context.Background(), func() error {
+		calls++
+		return &github.AbuseRateLimitError{RetryAfter: &retryAfter}
+	})
+
+	var abuseErr *github.AbuseRateLimitError
+	assert.ErrorAs(t, err, &abuseErr)
+	assert.Equal(t, 1, calls)
+}
@@ -4,10 +4,18 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"mime"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
 
 	"github.com/shaharia-lab/goai"
 	"go.opentelemetry.io/otel/attribute"
@@ -15,6 +23,12 @@ import (
 
 const (
 	GmailToolName = "gmail"
+
+	// gmailMaxDays is the maximum look-back window accepted by the list operation
+	gmailMaxDays = 20
+
+	// gmailFetchConcurrency bounds the number of concurrent message fetches in the list operation
+	gmailFetchConcurrency = 5
 )
 
 // Gmail represents a wrapper around the Gmail API service,
@@ -33,11 +47,30 @@ type EmailMessage struct {
 	Date    string `json:"date"`
 }
 
+// outgoingEmail holds the fields of an email to be sent
+type outgoingEmail struct {
+	To      string `json:"to,omitempty"`
+	Cc      string `json:"cc,omitempty"`
+	Bcc     string `json:"bcc,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+	HTML    bool   `json:"html,omitempty"`
+
+	// InReplyTo and References thread a reply to the message it answers
+	InReplyTo  string `json:"-"`
+	References string `json:"-"`
+	// ThreadID places a reply in the Gmail thread of the original message
+	ThreadID string `json:"-"`
+}
+
 // GmailConfig holds the configuration for the Gmail tool
 type GmailConfig struct {
 	UserID         string
 	MaxResults     int64
 	SinceLastNDays int
+	// PermanentDelete makes the delete operation remove messages permanently
+	// instead of moving them to the trash
+	PermanentDelete bool
 }
 
 // NewGmail creates and returns a new instance of the Gmail wrapper with the provided configuration.
@@ -51,20 +84,42 @@ func NewGmail(logger goai.Logger, service *gmail.Service, config GmailConfig) *G
 
 // GmailAllInOneTool returns a goai.Tool that can perform various Gmail operations
 func (g *Gmail) GmailAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GmailToolName,
-		Description: "Performs Gmail operations such as list, send, read messages",
+		Description: "Performs Gmail operations such as list, send, reply, read, delete and label messages, read whole threads and download attachments",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"description": "Gmail operation to execute (list, send, read) emails",
-					"enum": ["list", "send", "read"]
+					"description": "Gmail operation to execute (list, send, reply, read, delete, modify, get_attachment, get_thread) emails",
+					"enum": ["list", "send", "reply", "read", "delete", "modify", "get_attachment", "get_thread"]
 				},
 				"message_id": {
 					"type": "string",
-					"description": "Message ID for read operations"
+					"description": "Message ID for reply, read, delete, modify and get_attachment operations"
+				},
+				"thread_id": {
+					"type": "string",
+					"description": "Thread ID for get_thread operation"
+				},
+				"attachment_id": {
+					"type": "string",
+					"description": "Attachment ID for get_attachment operation"
+				},
+				"add_labels": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Label IDs to add to the message (for modify operation), e.g. STARRED, IMPORTANT"
+				},
+				"remove_labels": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Label IDs to remove from the message (for modify operation). Removing UNREAD marks the message as read"
+				},
+				"mark_read": {
+					"type": "boolean",
+					"description": "Mark the message as read (for modify operation). Shorthand for removing the UNREAD label"
 				},
 				"query": {
 					"type": "string",
@@ -72,10 +127,19 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 				},
 				"email": {
 					"type": "object",
+					"description": "Email to send. For reply the recipient and subject are taken from the original message",
 					"properties": {
 						"to": {
 							"type": "string",
-							"description": "Recipient email address"
+							"description": "Recipient email address. Multiple addresses may be comma-separated"
+						},
+						"cc": {
+							"type": "string",
+							"description": "Comma-separated CC recipient email addresses"
+						},
+						"bcc": {
+							"type": "string",
+							"description": "Comma-separated BCC recipient email addresses"
 						},
 						"subject": {
 							"type": "string",
@@ -84,6 +148,10 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 						"body": {
 							"type": "string",
 							"description": "Email body content"
+						},
+						"html": {
+							"type": "boolean",
+							"description": "Send the body as HTML instead of plain text"
 						}
 					}
 				},
@@ -93,7 +161,8 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 				},
 				"days": {
 					"type": "integer",
-					"description": "Consider messages since the last N days. Maximum 20 days allowed"
+					"description": "Consider messages since the last N days. Maximum 20 days allowed, larger values are rejected",
+					"maximum": 20
 				}
 			},
 			"required": ["operation"]
@@ -112,16 +181,17 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 			}).Info("Starting Gmail operation execution")
 
 			var input struct {
-				Operation  string `json:"operation"`
-				MessageID  string `json:"message_id,omitempty"`
-				Query      string `json:"query,omitempty"`
-				Days       int    `json:"days,omitempty"`
-				MaxResults int64  `json:"max_results,omitempty"`
-				Email      struct {
-					To      string `json:"to,omitempty"`
-					Subject string `json:"subject,omitempty"`
-					Body    string `json:"body,omitempty"`
-				} `json:"email,omitempty"`
+				Operation    string        `json:"operation"`
+				MessageID    string        `json:"message_id,omitempty"`
+				ThreadID     string        `json:"thread_id,omitempty"`
+				AttachmentID string        `json:"attachment_id,omitempty"`
+				Query        string        `json:"query,omitempty"`
+				Days         int           `json:"days,omitempty"`
+				MaxResults   int64         `json:"max_results,omitempty"`
+				AddLabels    []string      `json:"add_labels,omitempty"`
+				RemoveLabels []string      `json:"remove_labels,omitempty"`
+				MarkRead     bool          `json:"mark_read,omitempty"`
+				Email        outgoingEmail `json:"email,omitempty"`
 			}
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -131,7 +201,7 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 				}).Error("Failed to unmarshal input parameters")
 
 				span.RecordError(err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
 			}
 
 			var result string
@@ -139,13 +209,31 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 
 			switch input.Operation {
 			case "list":
+				if input.Days > gmailMaxDays {
+					err = validationErrorf("days must not exceed %d, got %d", gmailMaxDays, input.Days)
+					break
+				}
 				result, err = g.listMessages(ctx, input.Query, input.Days, input.MaxResults)
 			case "send":
-				result, err = g.sendMessage(ctx, input.Email.To, input.Email.Subject, input.Email.Body)
+				result, err = g.sendMessage(ctx, input.Email)
+			case "reply":
+				result, err = g.replyToMessage(ctx, input.MessageID, input.Email)
 			case "read":
 				result, err = g.readMessage(ctx, input.MessageID)
+			case "delete":
+				result, err = g.deleteMessage(ctx, input.MessageID)
+			case "get_attachment":
+				result, err = g.getAttachment(ctx, input.MessageID, input.AttachmentID)
+			case "get_thread":
+				result, err = g.getThread(ctx, input.ThreadID)
+			case "modify":
+				removeLabels := input.RemoveLabels
+				if input.MarkRead {
+					removeLabels = append(removeLabels, "UNREAD")
+				}
+				result, err = g.modifyMessage(ctx, input.MessageID, input.AddLabels, removeLabels)
 			default:
-				err = fmt.Errorf("unsupported operation: %s", input.Operation)
+				err = validationErrorf("unsupported operation: %s", input.Operation)
 			}
 
 			if err != nil {
@@ -171,7 +259,21 @@ func (g *Gmail) GmailAllInOneTool() goai.Tool {
 				}},
 			}, nil
 		},
+	})
+}
+
+// gmailToolError classifies a failed Gmail API call by its status
+func gmailToolError(err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusNotFound:
+			return newToolError(ErrorKindNotFound, err)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return newToolError(ErrorKindPermission, err)
+		}
 	}
+	return newToolError(ErrorKindUpstream, err)
 }
 
 func (g *Gmail) listMessages(ctx context.Context, query string, days int, maxResults int64) (string, error) {
@@ -201,47 +303,52 @@ func (g *Gmail) listMessages(ctx context.Context, query string, days int, maxRes
 
 	resp, err := req.Context(ctx).Do()
 	if err != nil {
-		return "", fmt.Errorf("failed to list messages: %w", err)
+		return "", gmailToolError(fmt.Errorf("failed to list messages: %w", err))
 	}
 
-	var messages []EmailMessage
-	for _, msg := range resp.Messages {
-		// Get full message details
-		fullMsg, err := g.service.Users.Messages.Get("me", msg.Id).
-			Format("full").
-			Context(ctx).
-			Do()
-		if err != nil {
-			g.logger.WithFields(map[string]interface{}{
-				goai.ErrorLogField: err,
-				"message_id":                msg.Id,
-			}).Error("Failed to fetch message details")
-			continue
-		}
+	// Fetch headers concurrently, keeping results in the order returned by the list call
+	fetched := make([]*EmailMessage, len(resp.Messages))
+	var group errgroup.Group
+	group.SetLimit(gmailFetchConcurrency)
+
+	for i, msg := range resp.Messages {
+		group.Go(func() error {
+			metaMsg, err := g.service.Users.Messages.Get("me", msg.Id).
+				Format("metadata").
+				MetadataHeaders("From", "Subject", "Date").
+				Context(ctx).
+				Do()
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"message_id":       msg.Id,
+				}).Error("Failed to fetch message details")
+				return nil
+			}
 
-		// Extract headers
-		var from, subject, date string
-		if fullMsg.Payload != nil {
-			for _, header := range fullMsg.Payload.Headers {
-				switch header.Name {
-				case "From":
-					from = header.Value
-				case "Subject":
-					subject = header.Value
-				case "Date":
-					date = header.Value
-				}
+			var headers []*gmail.MessagePartHeader
+			if metaMsg.Payload != nil {
+				headers = metaMsg.Payload.Headers
 			}
-		}
 
-		messages = append(messages, EmailMessage{
-			ID:      fullMsg.Id,
-			From:    from,
-			Subject: subject,
-			Snippet: fullMsg.Snippet,
-			Date:    date,
+			fetched[i] = &EmailMessage{
+				ID:      metaMsg.Id,
+				From:    headerValue(headers, "From"),
+				Subject: headerValue(headers, "Subject"),
+				Snippet: metaMsg.Snippet,
+				Date:    headerValue(headers, "Date"),
+			}
+			return nil
 		})
 	}
+	_ = group.Wait()
+
+	var messages []EmailMessage
+	for _, msg := range fetched {
+		if msg != nil {
+			messages = append(messages, *msg)
+		}
+	}
 
 	// If no messages found
 	if len(messages) == 0 {
@@ -251,46 +358,395 @@ func (g *Gmail) listMessages(ctx context.Context, query string, days int, maxRes
 	// Convert to JSON for formatted output
 	jsonOutput, err := json.MarshalIndent(messages, "", "  ")
 	if err != nil {
-		return "", fmt.Errorf("failed to format messages: %w", err)
+		return "", newToolError(ErrorKindInternal, fmt.Errorf("failed to format messages: %w", err))
 	}
 
 	return string(jsonOutput), nil
 }
 
-func (g *Gmail) sendMessage(ctx context.Context, to, subject, body string) (string, error) {
-	// Implement email sending logic using gmail.Service
-	// This is a simplified version - you'll need to properly construct the email
+func (g *Gmail) sendMessage(ctx context.Context, email outgoingEmail) (string, error) {
+	raw, err := createEncodedEmail(email)
+	if err != nil {
+		return "", err
+	}
+
 	message := gmail.Message{
-		Raw: createEncodedEmail(to, subject, body), // You'll need to implement this helper
+		Raw:      raw,
+		ThreadId: email.ThreadID,
 	}
 
-	resp, err := g.service.Users.Messages.Send("me", &message).Do()
+	resp, err := g.service.Users.Messages.Send("me", &message).Context(ctx).Do()
 	if err != nil {
-		return "", err
+		return "", gmailToolError(fmt.Errorf("failed to send message: %w", err))
 	}
 
 	return fmt.Sprintf("Message sent successfully. ID: %s", resp.Id), nil
 }
 
+// replyToMessage sends a reply to the original message's sender within the
+// same thread, setting the In-Reply-To and References headers so mail clients
+// thread it as well
+func (g *Gmail) replyToMessage(ctx context.Context, messageID string, email outgoingEmail) (string, error) {
+	if messageID == "" {
+		return "", validationErrorf("message_id is required for reply operation")
+	}
+
+	original, err := g.service.Users.Messages.Get("me", messageID).
+		Format("metadata").
+		MetadataHeaders("Message-ID", "Subject", "From", "Reply-To", "References").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to fetch original message: %w", err))
+	}
+
+	var headers []*gmail.MessagePartHeader
+	if original.Payload != nil {
+		headers = original.Payload.Headers
+	}
+
+	email.To = headerValue(headers, "Reply-To")
+	if email.To == "" {
+		email.To = headerValue(headers, "From")
+	}
+
+	email.Subject = headerValue(headers, "Subject")
+	if !strings.HasPrefix(strings.ToLower(email.Subject), "re:") {
+		email.Subject = "Re: " + email.Subject
+	}
+
+	originalID := headerValue(headers, "Message-ID")
+	email.InReplyTo = originalID
+	email.References = strings.TrimSpace(headerValue(headers, "References") + " " + originalID)
+	email.ThreadID = original.ThreadId
+
+	return g.sendMessage(ctx, email)
+}
+
 func (g *Gmail) readMessage(ctx context.Context, messageID string) (string, error) {
-	msg, err := g.service.Users.Messages.Get("me", messageID).Do()
+	msg, err := g.service.Users.Messages.Get("me", messageID).
+		Format("full").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to read message: %w", err))
+	}
+
+	if msg.Payload == nil {
+		return fmt.Sprintf("Message snippet: %s", msg.Snippet), nil
+	}
+
+	body, err := extractMessageBody(msg.Payload)
+	if err != nil {
+		return "", upstreamErrorf("failed to decode message body: %w", err)
+	}
+	if body == "" {
+		body = msg.Snippet
+	}
+
+	var result strings.Builder
+	for _, name := range []string{"From", "To", "Subject", "Date"} {
+		result.WriteString(fmt.Sprintf("%s: %s\n", name, headerValue(msg.Payload.Headers, name)))
+	}
+	result.WriteString("\n")
+	result.WriteString(body)
+
+	return result.String(), nil
+}
+
+// gmailThreadMessage is a single message of the get_thread operation result
+type gmailThreadMessage struct {
+	ID      string `json:"id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Date    string `json:"date"`
+	Body    string `json:"body"`
+}
+
+// getThread returns every message of a thread in chronological order with its
+// decoded body, falling back to the snippet when a message has no text body
+func (g *Gmail) getThread(ctx context.Context, threadID string) (string, error) {
+	if threadID == "" {
+		return "", validationErrorf("thread_id is required for get_thread operation")
+	}
+
+	thread, err := g.service.Users.Threads.Get("me", threadID).
+		Format("full").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to fetch thread: %w", err))
+	}
+
+	sort.SliceStable(thread.Messages, func(i, j int) bool {
+		return thread.Messages[i].InternalDate < thread.Messages[j].InternalDate
+	})
+
+	messages := make([]gmailThreadMessage, 0, len(thread.Messages))
+	for _, msg := range thread.Messages {
+		message := gmailThreadMessage{ID: msg.Id, Body: msg.Snippet}
+		if msg.Payload != nil {
+			headers := msg.Payload.Headers
+			message.From = headerValue(headers, "From")
+			message.To = headerValue(headers, "To")
+			message.Subject = headerValue(headers, "Subject")
+			message.Date = headerValue(headers, "Date")
+
+			body, err := extractMessageBody(msg.Payload)
+			if err != nil {
+				return "", upstreamErrorf("failed to decode body of message %s: %w", msg.Id, err)
+			}
+			if body != "" {
+				message.Body = body
+			}
+		}
+		messages = append(messages, message)
+	}
+
+	result, err := json.MarshalIndent(map[string]interface{}{
+		"thread_id": thread.Id,
+		"messages":  messages,
+	}, "", "  ")
+	if err != nil {
+		return "", newToolError(ErrorKindInternal, fmt.Errorf("failed to format thread: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// gmailAttachment is the result of the get_attachment operation
+type gmailAttachment struct {
+	Filename string `json:"filename,omitempty"`
+	MimeType string `json:"mime"`
+	Size     int64  `json:"size"`
+	Encoding string `json:"encoding"`
+	Data     string `json:"data"`
+}
+
+// getAttachment downloads an attachment and returns it base64 encoded along
+// with the filename and MIME type of the message part it belongs to
+func (g *Gmail) getAttachment(ctx context.Context, messageID, attachmentID string) (string, error) {
+	if messageID == "" || attachmentID == "" {
+		return "", validationErrorf("message_id and attachment_id are required for get_attachment operation")
+	}
+
+	body, err := g.service.Users.Messages.Attachments.Get("me", messageID, attachmentID).Context(ctx).Do()
+	if err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to fetch attachment: %w", err))
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(body.Data, "="))
+	if err != nil {
+		return "", upstreamErrorf("failed to decode attachment: %w", err)
+	}
+
+	attachment := gmailAttachment{
+		Size:     int64(len(data)),
+		Encoding: "base64",
+		Data:     base64.StdEncoding.EncodeToString(data),
+	}
+
+	msg, err := g.service.Users.Messages.Get("me", messageID).Format("full").Context(ctx).Do()
+	if err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to fetch message: %w", err))
+	}
+	if part := findAttachmentPart(msg.Payload, attachmentID); part != nil {
+		attachment.Filename = part.Filename
+		attachment.MimeType = part.MimeType
+	}
+	if attachment.MimeType == "" {
+		attachment.MimeType = http.DetectContentType(data)
+	}
+
+	result, err := json.Marshal(attachment)
+	if err != nil {
+		return "", newToolError(ErrorKindInternal, fmt.Errorf("failed to format attachment: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// findAttachmentPart walks the message part tree and returns the part holding the attachment
+func findAttachmentPart(part *gmail.MessagePart, attachmentID string) *gmail.MessagePart {
+	if part == nil {
+		return nil
+	}
+	if part.Body != nil && part.Body.AttachmentId == attachmentID {
+		return part
+	}
+	for _, child := range part.Parts {
+		if found := findAttachmentPart(child, attachmentID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// extractMessageBody returns the decoded text/plain body of a message,
+// falling back to the text/html body when no plain text part exists
+func extractMessageBody(payload *gmail.MessagePart) (string, error) {
+	part := findMessagePart(payload, "text/plain")
+	if part == nil {
+		part = findMessagePart(payload, "text/html")
+	}
+	if part == nil || part.Body == nil || part.Body.Data == "" {
+		return "", nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part.Body.Data, "="))
 	if err != nil {
 		return "", err
 	}
 
-	// Format the message content as needed
-	return fmt.Sprintf("Message snippet: %s", msg.Snippet), nil
+	return string(data), nil
+}
+
+// findMessagePart walks the message part tree depth-first and returns the
+// first part with the given MIME type
+func findMessagePart(part *gmail.MessagePart, mimeType string) *gmail.MessagePart {
+	if part == nil {
+		return nil
+	}
+	if strings.EqualFold(part.MimeType, mimeType) && part.Body != nil && part.Body.Data != "" {
+		return part
+	}
+	for _, child := range part.Parts {
+		if found := findMessagePart(child, mimeType); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// headerValue returns the value of the named header, or an empty string if absent
+func headerValue(headers []*gmail.MessagePartHeader, name string) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return header.Value
+		}
+	}
+	return ""
+}
+
+// deleteMessage moves the message to the trash, or deletes it permanently
+// when PermanentDelete is configured
+func (g *Gmail) deleteMessage(ctx context.Context, messageID string) (string, error) {
+	if messageID == "" {
+		return "", validationErrorf("message_id is required for delete operation")
+	}
+
+	if g.config.PermanentDelete {
+		if err := g.service.Users.Messages.Delete("me", messageID).Context(ctx).Do(); err != nil {
+			return "", gmailToolError(fmt.Errorf("failed to delete message: %w", err))
+		}
+		return fmt.Sprintf("Message permanently deleted. ID: %s", messageID), nil
+	}
+
+	if _, err := g.service.Users.Messages.Trash("me", messageID).Context(ctx).Do(); err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to trash message: %w", err))
+	}
+
+	return fmt.Sprintf("Message moved to trash. ID: %s", messageID), nil
+}
+
+// modifyMessage adds and removes labels on a message
+func (g *Gmail) modifyMessage(ctx context.Context, messageID string, addLabels, removeLabels []string) (string, error) {
+	if messageID == "" {
+		return "", validationErrorf("message_id is required for modify operation")
+	}
+	if len(addLabels) == 0 && len(removeLabels) == 0 {
+		return "", validationErrorf("at least one of add_labels, remove_labels or mark_read is required for modify operation")
+	}
+
+	msg, err := g.service.Users.Messages.Modify("me", messageID, &gmail.ModifyMessageRequest{
+		AddLabelIds:    addLabels,
+		RemoveLabelIds: removeLabels,
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", gmailToolError(fmt.Errorf("failed to modify message: %w", err))
+	}
+
+	return fmt.Sprintf("Message labels updated. ID: %s, labels: %s", msg.Id, strings.Join(msg.LabelIds, ", ")), nil
 }
 
-func createEncodedEmail(to, subject, body string) string {
+// createEncodedEmail builds the RFC 5322 message for the email, rejecting
+// header values that could inject further headers
+func createEncodedEmail(email outgoingEmail) (string, error) {
+	contentType := "text/plain"
+	if email.HTML {
+		contentType = "text/html"
+	}
+
+	to, err := formatAddressList("to", email.To)
+	if err != nil {
+		return "", err
+	}
+	cc, err := formatAddressList("cc", email.Cc)
+	if err != nil {
+		return "", err
+	}
+	bcc, err := formatAddressList("bcc", email.Bcc)
+	if err != nil {
+		return "", err
+	}
+	headers := []struct{ name, value string }{
+		{"Subject", email.Subject},
+		{"In-Reply-To", email.InReplyTo},
+		{"References", email.References},
+	}
+	for _, header := range headers {
+		if strings.ContainsAny(header.value, "\r\n") {
+			return "", validationErrorf("%s header must not contain line breaks", header.name)
+		}
+	}
+
 	// Create email message according to RFC 5322
-	message := fmt.Sprintf("From: me\r\n"+
-		"To: %s\r\n"+
-		"Subject: %s\r\n"+
-		"Content-Type: text/plain; charset=UTF-8\r\n"+
-		"\r\n"+
-		"%s", to, subject, body)
+	var message strings.Builder
+	message.WriteString("From: me\r\n")
+	message.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	if cc != "" {
+		message.WriteString(fmt.Sprintf("Cc: %s\r\n", cc))
+	}
+	if bcc != "" {
+		message.WriteString(fmt.Sprintf("Bcc: %s\r\n", bcc))
+	}
+	// Non-ASCII subjects are sent as RFC 2047 encoded words
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject)))
+	if email.InReplyTo != "" {
+		message.WriteString(fmt.Sprintf("In-Reply-To: %s\r\n", email.InReplyTo))
+	}
+	if email.References != "" {
+		message.WriteString(fmt.Sprintf("References: %s\r\n", email.References))
+	}
+	message.WriteString(fmt.Sprintf("Content-Type: %s; charset=UTF-8\r\n", contentType))
+	message.WriteString("\r\n")
+	message.WriteString(email.Body)
 
 	// Encode to base64URL
-	return base64.URLEncoding.EncodeToString([]byte(message))
+	return base64.URLEncoding.EncodeToString([]byte(message.String())), nil
+}
+
+// formatAddressList parses a comma-separated list of addresses and formats it
+// in the ", " separated form used in RFC 5322 address headers. Parsing rejects
+// anything but addresses, such as line breaks starting another header.
+func formatAddressList(field, addresses string) (string, error) {
+	if strings.TrimSpace(addresses) == "" {
+		return "", nil
+	}
+
+	parsed, err := mail.ParseAddressList(addresses)
+	if err != nil {
+		return "", validationErrorf("invalid %s address list: %v", field, err)
+	}
+
+	list := make([]string, len(parsed))
+	for i, address := range parsed {
+		if address.Name == "" {
+			list[i] = address.Address
+		} else {
+			list[i] = address.String()
+		}
+	}
+	return strings.Join(list, ", "), nil
 }
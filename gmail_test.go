@@ -0,0 +1,644 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+func setupGmailTest(t *testing.T, config GmailConfig) (*Gmail, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	service, err := gmail.NewService(context.Background(),
+		option.WithEndpoint(server.URL),
+		option.WithHTTPClient(server.Client()),
+	)
+	require.NoError(t, err)
+
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Debug", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	return NewGmail(mockLogger, service, config), mux, server.Close
+}
+
+func callGmailTool(t *testing.T, g *Gmail, input map[string]interface{}) goai.CallToolResult {
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := g.GmailAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      GmailToolName,
+		Arguments: inputBytes,
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestGmail_DeleteMovesToTrash(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	trashed := false
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1/trash", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		trashed = true
+
+		err := json.NewEncoder(w).Encode(&gmail.Message{Id: "msg-1", LabelIds: []string{"TRASH"}})
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "delete",
+		"message_id": "msg-1",
+	})
+
+	assert.False(t, result.IsError)
+	assert.True(t, trashed)
+	assert.Contains(t, result.Content[0].Text, "moved to trash")
+}
+
+func TestGmail_DeletePermanent(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{PermanentDelete: true})
+	defer cleanup()
+
+	deleted := false
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "delete",
+		"message_id": "msg-1",
+	})
+
+	assert.False(t, result.IsError)
+	assert.True(t, deleted)
+	assert.Contains(t, result.Content[0].Text, "permanently deleted")
+}
+
+func TestGmail_ReadMultipartMessage(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "full", r.URL.Query().Get("format"))
+
+		msg := &gmail.Message{
+			Id:      "msg-1",
+			Snippet: "Hello there...",
+			Payload: &gmail.MessagePart{
+				MimeType: "multipart/alternative",
+				Headers: []*gmail.MessagePartHeader{
+					{Name: "From", Value: "alice@example.com"},
+					{Name: "To", Value: "bob@example.com"},
+					{Name: "Subject", Value: "Greetings"},
+					{Name: "Date", Value: "Mon, 1 Jan 2024 10:00:00 +0000"},
+				},
+				Parts: []*gmail.MessagePart{
+					{
+						MimeType: "text/html",
+						Body:     &gmail.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte("<p>Hello there, Bob!</p>"))},
+					},
+					{
+						MimeType: "text/plain",
+						Body:     &gmail.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte("Hello there, Bob! This is the full body."))},
+					},
+				},
+			},
+		}
+		err := json.NewEncoder(w).Encode(msg)
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "read",
+		"message_id": "msg-1",
+	})
+
+	require.False(t, result.IsError)
+	text := result.Content[0].Text
+	assert.Contains(t, text, "From: alice@example.com")
+	assert.Contains(t, text, "To: bob@example.com")
+	assert.Contains(t, text, "Subject: Greetings")
+	assert.Contains(t, text, "Hello there, Bob! This is the full body.")
+	assert.NotContains(t, text, "<p>")
+}
+
+func TestGmail_ReadSinglePartHTMLMessage(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-2", func(w http.ResponseWriter, r *http.Request) {
+		msg := &gmail.Message{
+			Id: "msg-2",
+			Payload: &gmail.MessagePart{
+				MimeType: "text/html",
+				Headers:  []*gmail.MessagePartHeader{{Name: "Subject", Value: "HTML only"}},
+				Body:     &gmail.MessagePartBody{Data: base64.RawURLEncoding.EncodeToString([]byte("<b>Bold?</b>"))},
+			},
+		}
+		err := json.NewEncoder(w).Encode(msg)
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "read",
+		"message_id": "msg-2",
+	})
+
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "Subject: HTML only")
+	assert.Contains(t, result.Content[0].Text, "<b>Bold?</b>")
+}
+
+func TestGmail_ListDaysLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		days        int
+		expectError bool
+	}{
+		{name: "maximum days accepted", days: 20, expectError: false},
+		{name: "days above maximum rejected", days: 30, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+			defer cleanup()
+
+			listCalled := false
+			mux.HandleFunc("/gmail/v1/users/me/messages", func(w http.ResponseWriter, r *http.Request) {
+				listCalled = true
+				assert.Contains(t, r.URL.Query().Get("q"), "after:")
+
+				err := json.NewEncoder(w).Encode(&gmail.ListMessagesResponse{})
+				assert.NoError(t, err)
+			})
+
+			result := callGmailTool(t, g, map[string]interface{}{
+				"operation": "list",
+				"days":      tt.days,
+			})
+
+			assert.Equal(t, tt.expectError, result.IsError)
+			assert.Equal(t, !tt.expectError, listCalled)
+			if tt.expectError {
+				assert.Contains(t, result.Content[0].Text, "days: Must be less than or equal to 20")
+			}
+		})
+	}
+}
+
+func TestCreateEncodedEmail(t *testing.T) {
+	tests := []struct {
+		name        string
+		email       outgoingEmail
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "plain text single recipient",
+			email: outgoingEmail{
+				To:      "bob@example.com",
+				Subject: "Hello",
+				Body:    "Plain body",
+			},
+			contains: []string{
+				"To: bob@example.com\r\n",
+				"Subject: Hello\r\n",
+				"Content-Type: text/plain; charset=UTF-8\r\n",
+				"\r\n\r\nPlain body",
+			},
+			notContains: []string{"Cc:", "Bcc:"},
+		},
+		{
+			name: "html with cc and bcc lists",
+			email: outgoingEmail{
+				To:      "bob@example.com, carol@example.com",
+				Cc:      "dave@example.com,erin@example.com",
+				Bcc:     " frank@example.com ",
+				Subject: "Team update",
+				Body:    "<h1>Update</h1>",
+				HTML:    true,
+			},
+			contains: []string{
+				"To: bob@example.com, carol@example.com\r\n",
+				"Cc: dave@example.com, erin@example.com\r\n",
+				"Bcc: frank@example.com\r\n",
+				"Content-Type: text/html; charset=UTF-8\r\n",
+				"<h1>Update</h1>",
+			},
+		},
+		{
+			name: "named recipient and non-ASCII subject",
+			email: outgoingEmail{
+				To:      "Bob Smith <bob@example.com>",
+				Subject: "Grüße",
+				Body:    "Hallo",
+			},
+			contains: []string{
+				`To: "Bob Smith" <bob@example.com>` + "\r\n",
+				"Subject: =?utf-8?q?Gr=C3=BC=C3=9Fe?=\r\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := createEncodedEmail(tt.email)
+			require.NoError(t, err)
+			raw, err := base64.URLEncoding.DecodeString(encoded)
+			require.NoError(t, err)
+
+			message := string(raw)
+			for _, s := range tt.contains {
+				assert.Contains(t, message, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, message, s)
+			}
+		})
+	}
+}
+
+func TestCreateEncodedEmail_RejectsHeaderInjection(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    outgoingEmail
+		expected string
+	}{
+		{
+			name:     "line break in cc",
+			email:    outgoingEmail{To: "bob@example.com", Cc: "carol@example.com\r\nBcc: eve@example.com", Subject: "Hi"},
+			expected: "invalid cc address list",
+		},
+		{
+			name:     "header in to",
+			email:    outgoingEmail{To: "bob@example.com\nX-Injected: yes", Subject: "Hi"},
+			expected: "invalid to address list",
+		},
+		{
+			name:     "line break in bcc",
+			email:    outgoingEmail{To: "bob@example.com", Bcc: "carol@example.com\r\n\r\nbody", Subject: "Hi"},
+			expected: "invalid bcc address list",
+		},
+		{
+			name:     "line break in subject",
+			email:    outgoingEmail{To: "bob@example.com", Subject: "Hi\r\nBcc: eve@example.com"},
+			expected: "Subject header must not contain line breaks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := createEncodedEmail(tt.email)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			kind, ok := ErrorKindOf(err)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+		})
+	}
+}
+
+func TestGmail_ModifyLabels(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1/modify", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var req gmail.ModifyMessageRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"STARRED", "Label_42"}, req.AddLabelIds)
+		assert.Equal(t, []string{"INBOX", "UNREAD"}, req.RemoveLabelIds)
+
+		err = json.NewEncoder(w).Encode(&gmail.Message{Id: "msg-1", LabelIds: []string{"STARRED", "Label_42"}})
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":     "modify",
+		"message_id":    "msg-1",
+		"add_labels":    []string{"STARRED", "Label_42"},
+		"remove_labels": []string{"INBOX"},
+		"mark_read":     true,
+	})
+
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "STARRED, Label_42")
+}
+
+func TestGmail_ModifyRequiresLabels(t *testing.T) {
+	g, _, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "modify",
+		"message_id": "msg-1",
+	})
+
+	assert.True(t, result.IsError)
+}
+
+func TestGmail_ListFetchesMetadataInOrder(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	ids := []string{"msg-1", "msg-2", "msg-3", "msg-4", "msg-5", "msg-6"}
+
+	mux.HandleFunc("/gmail/v1/users/me/messages", func(w http.ResponseWriter, r *http.Request) {
+		var messages []*gmail.Message
+		for _, id := range ids {
+			messages = append(messages, &gmail.Message{Id: id})
+		}
+		err := json.NewEncoder(w).Encode(&gmail.ListMessagesResponse{Messages: messages})
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/gmail/v1/users/me/messages/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "metadata", r.URL.Query().Get("format"))
+		assert.ElementsMatch(t, []string{"From", "Subject", "Date"}, r.URL.Query()["metadataHeaders"])
+
+		id := strings.TrimPrefix(r.URL.Path, "/gmail/v1/users/me/messages/")
+		// Respond to earlier messages more slowly to shuffle completion order
+		for i, candidate := range ids {
+			if candidate == id {
+				time.Sleep(time.Duration(len(ids)-i) * 5 * time.Millisecond)
+			}
+		}
+
+		msg := &gmail.Message{
+			Id: id,
+			Payload: &gmail.MessagePart{
+				Headers: []*gmail.MessagePartHeader{
+					{Name: "From", Value: "sender@example.com"},
+					{Name: "Subject", Value: "Subject " + id},
+				},
+			},
+		}
+		err := json.NewEncoder(w).Encode(msg)
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation": "list",
+	})
+
+	require.False(t, result.IsError)
+
+	var messages []EmailMessage
+	err := json.Unmarshal([]byte(result.Content[0].Text), &messages)
+	require.NoError(t, err)
+	require.Len(t, messages, len(ids))
+	for i, msg := range messages {
+		assert.Equal(t, ids[i], msg.ID)
+		assert.Equal(t, "Subject "+ids[i], msg.Subject)
+		assert.Equal(t, "sender@example.com", msg.From)
+	}
+}
+
+func TestGmail_ReplyPreservesThread(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "metadata", r.URL.Query().Get("format"))
+
+		err := json.NewEncoder(w).Encode(&gmail.Message{
+			Id:       "msg-1",
+			ThreadId: "thread-9",
+			Payload: &gmail.MessagePart{
+				Headers: []*gmail.MessagePartHeader{
+					{Name: "From", Value: "alice@example.com"},
+					{Name: "Subject", Value: "Quarterly report"},
+					{Name: "Message-ID", Value: "<orig@example.com>"},
+					{Name: "References", Value: "<first@example.com>"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	var sent gmail.Message
+	mux.HandleFunc("/gmail/v1/users/me/messages/send", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+
+		err := json.NewEncoder(w).Encode(&gmail.Message{Id: "reply-1", ThreadId: sent.ThreadId})
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "reply",
+		"message_id": "msg-1",
+		"email":      map[string]interface{}{"body": "Thanks, looks good."},
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "Message sent successfully. ID: reply-1", result.Content[0].Text)
+	assert.Equal(t, "thread-9", sent.ThreadId)
+
+	raw, err := base64.URLEncoding.DecodeString(sent.Raw)
+	require.NoError(t, err)
+	message := string(raw)
+	assert.Contains(t, message, "To: alice@example.com\r\n")
+	assert.Contains(t, message, "Subject: Re: Quarterly report\r\n")
+	assert.Contains(t, message, "In-Reply-To: <orig@example.com>\r\n")
+	assert.Contains(t, message, "References: <first@example.com> <orig@example.com>\r\n")
+	assert.Contains(t, message, "Thanks, looks good.")
+}
+
+func TestGmail_ReplyRequiresMessageID(t *testing.T) {
+	g, _, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation": "reply",
+		"email":     map[string]interface{}{"body": "Hi"},
+	})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, "message_id is required for reply operation", result.Content[0].Text)
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindValidation, kind)
+}
+
+func TestGmail_ReadMissingMessageIsNotFound(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	mux.HandleFunc("/gmail/v1/users/me/messages/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":  "read",
+		"message_id": "missing",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "failed to read message")
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindNotFound, kind)
+}
+
+func TestGmail_GetAttachment(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	content := []byte("%PDF-1.4 report")
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1/attachments/att-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		err := json.NewEncoder(w).Encode(&gmail.MessagePartBody{
+			AttachmentId: "att-1",
+			Size:         int64(len(content)),
+			Data:         base64.URLEncoding.EncodeToString(content),
+		})
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/gmail/v1/users/me/messages/msg-1", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&gmail.Message{
+			Id: "msg-1",
+			Payload: &gmail.MessagePart{
+				MimeType: "multipart/mixed",
+				Parts: []*gmail.MessagePart{
+					{MimeType: "text/plain", Body: &gmail.MessagePartBody{Data: "aGk"}},
+					{MimeType: "application/pdf", Filename: "report.pdf", Body: &gmail.MessagePartBody{AttachmentId: "att-1"}},
+				},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation":     "get_attachment",
+		"message_id":    "msg-1",
+		"attachment_id": "att-1",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var attachment gmailAttachment
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &attachment))
+	assert.Equal(t, "report.pdf", attachment.Filename)
+	assert.Equal(t, "application/pdf", attachment.MimeType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	assert.Equal(t, "base64", attachment.Encoding)
+
+	decoded, err := base64.StdEncoding.DecodeString(attachment.Data)
+	require.NoError(t, err)
+	assert.Equal(t, content, decoded)
+}
+
+func TestGmail_GetThread(t *testing.T) {
+	g, mux, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	encode := func(body string) string {
+		return base64.URLEncoding.EncodeToString([]byte(body))
+	}
+
+	mux.HandleFunc("/gmail/v1/users/me/threads/thread-7", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "full", r.URL.Query().Get("format"))
+
+		// The reply is listed first to check the messages are ordered by date
+		thread := &gmail.Thread{
+			Id: "thread-7",
+			Messages: []*gmail.Message{
+				{
+					Id:           "msg-2",
+					InternalDate: 1704103200000,
+					Snippet:      "Sounds good",
+					Payload: &gmail.MessagePart{
+						MimeType: "text/plain",
+						Headers: []*gmail.MessagePartHeader{
+							{Name: "From", Value: "bob@example.com"},
+							{Name: "To", Value: "alice@example.com"},
+							{Name: "Subject", Value: "Re: Lunch"},
+						},
+						Body: &gmail.MessagePartBody{Data: encode("Sounds good, see you at noon.")},
+					},
+				},
+				{
+					Id:           "msg-1",
+					InternalDate: 1704099600000,
+					Snippet:      "Lunch tomorrow?",
+					Payload: &gmail.MessagePart{
+						MimeType: "multipart/alternative",
+						Headers: []*gmail.MessagePartHeader{
+							{Name: "From", Value: "alice@example.com"},
+							{Name: "To", Value: "bob@example.com"},
+							{Name: "Subject", Value: "Lunch"},
+						},
+						Parts: []*gmail.MessagePart{
+							{MimeType: "text/html", Body: &gmail.MessagePartBody{Data: encode("<p>Lunch tomorrow?</p>")}},
+							{MimeType: "text/plain", Body: &gmail.MessagePartBody{Data: encode("Lunch tomorrow?")}},
+						},
+					},
+				},
+			},
+		}
+		err := json.NewEncoder(w).Encode(thread)
+		assert.NoError(t, err)
+	})
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation": "get_thread",
+		"thread_id": "thread-7",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var output struct {
+		ThreadID string               `json:"thread_id"`
+		Messages []gmailThreadMessage `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &output))
+	assert.Equal(t, "thread-7", output.ThreadID)
+	require.Len(t, output.Messages, 2)
+	assert.Equal(t, gmailThreadMessage{
+		ID:      "msg-1",
+		From:    "alice@example.com",
+		To:      "bob@example.com",
+		Subject: "Lunch",
+		Body:    "Lunch tomorrow?",
+	}, output.Messages[0])
+	assert.Equal(t, "msg-2", output.Messages[1].ID)
+	assert.Equal(t, "Sounds good, see you at noon.", output.Messages[1].Body)
+}
+
+func TestGmail_GetThreadRequiresThreadID(t *testing.T) {
+	g, _, cleanup := setupGmailTest(t, GmailConfig{})
+	defer cleanup()
+
+	result := callGmailTool(t, g, map[string]interface{}{
+		"operation": "get_thread",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "thread_id is required for get_thread operation")
+}
@@ -1,11 +1,18 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/shaharia-lab/goai"
 )
@@ -16,19 +23,36 @@ const GrepToolName = "grep"
 type Grep struct {
 	logger      goai.Logger
 	cmdExecutor CommandExecutor
+	config      GrepConfig
+}
+
+// GrepConfig holds the configuration for the Grep tool
+type GrepConfig struct {
+	AllowedDirectory string // Base directory searches are restricted to; empty means unrestricted
+	UseNativeSearch  bool   // Search with Go's regexp package instead of the external grep binary
+
+	// CommandExecutor runs the grep binary, e.g. a TimeoutCommandExecutor to bound
+	// its duration. Nil uses RealCommandExecutor.
+	CommandExecutor CommandExecutor
 }
 
 // NewGrep creates and returns a new instance of the Grep wrapper
 func NewGrep(logger goai.Logger) *Grep {
+	return NewGrepWithConfig(logger, GrepConfig{})
+}
+
+// NewGrepWithConfig creates and returns a new instance of the Grep wrapper with the given configuration
+func NewGrepWithConfig(logger goai.Logger, config GrepConfig) *Grep {
 	return &Grep{
 		logger:      logger,
-		cmdExecutor: &RealCommandExecutor{},
+		cmdExecutor: commandExecutorOrDefault(config.CommandExecutor),
+		config:      config,
 	}
 }
 
 // GrepAllInOneTool returns a goai.Tool that can execute grep commands
 func (g *Grep) GrepAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(g.logger, goai.Tool{
 		Name:        GrepToolName,
 		Description: "Execute grep commands with specified pattern and options",
 		InputSchema: json.RawMessage(`{
@@ -47,7 +71,15 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
                     "items": {
                         "type": "string"
                     },
-                    "description": "Additional grep options (e.g., -r for recursive, -i for case-insensitive)"
+                    "description": "Additional grep flags (e.g., -r for recursive, -i for case-insensitive); values must be attached, as in -A3. Flags reading patterns or files (-e, -f, --exclude-from) are not allowed"
+                },
+                "structured": {
+                    "type": "boolean",
+                    "description": "Return matches as a JSON array of {file, line, text} objects instead of raw grep output"
+                },
+                "max_matches": {
+                    "type": "integer",
+                    "description": "Maximum number of matching lines to return across all files. Zero means unlimited"
                 }
             },
             "required": ["pattern", "path"]
@@ -57,9 +89,11 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 			defer span.End()
 
 			var input struct {
-				Pattern string   `json:"pattern"`
-				Path    string   `json:"path"`
-				Options []string `json:"options"`
+				Pattern    string   `json:"pattern"`
+				Path       string   `json:"path"`
+				Options    []string `json:"options"`
+				Structured bool     `json:"structured"`
+				MaxMatches int      `json:"max_matches"`
 			}
 
 			g.logger.WithFields(map[string]interface{}{
@@ -73,10 +107,17 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 					"raw_input":                 string(params.Arguments),
 				}).Error("Failed to unmarshal input parameters")
 				span.RecordError(err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to parse input: %w", err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to parse input: %w", err))
 			}
 
-			if err := validateGrepInput(input); err != nil {
+			err := validateGrepInput(input.Pattern, input.Path)
+			if err == nil {
+				err = validateGrepOptions(input.Options)
+			}
+			if err == nil && input.MaxMatches < 0 {
+				err = validationErrorf("max_matches must not be negative")
+			}
+			if err != nil {
 				g.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
 				}).Error("Input validation failed")
@@ -84,6 +125,20 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 				return returnErrorOutput(err), nil
 			}
 
+			allowed, err := isPathWithinDirectory(input.Path, g.config.AllowedDirectory)
+			if err == nil && !allowed {
+				err = permissionErrorf("path outside allowed directory: %s", input.Path)
+			}
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField:  err,
+					"path":              input.Path,
+					"allowed_directory": g.config.AllowedDirectory,
+				}).Error("Access denied")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
 			// Ensure recursive search is enabled if a directory is provided
 			hasRecursive := false
 			for _, opt := range input.Options {
@@ -97,7 +152,11 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 			}
 
 			args := append(input.Options, "-E")
-			args = append(args, input.Pattern, input.Path)
+			if input.Structured {
+				args = append(args, "-n", "-H")
+			}
+			// "--" keeps a pattern or path starting with "-" from being parsed as an option
+			args = append(args, "--", input.Pattern, input.Path)
 
 			g.logger.WithFields(map[string]interface{}{
 				"tool":    GrepToolName,
@@ -105,10 +164,40 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 				"args":    args,
 			}).Info("Executing grep command", "args", args)
 
-			cmd := exec.Command("grep", args...)
+			var output []byte
+			if g.config.UseNativeSearch {
+				var matched bool
+				output, matched, err = searchNative(ctx, input.Pattern, input.Path, args[:len(args)-3])
+				if err != nil {
+					g.logger.WithFields(map[string]interface{}{
+						goai.ErrorLogField: err,
+						"args":             args,
+					}).Error("Native grep search failed")
+					span.RecordError(err)
+					return returnErrorOutput(newToolError(ErrorKindUpstream, err)), nil
+				}
+				if !matched {
+					return goai.CallToolResult{
+						Content: []goai.ToolResultContent{
+							{
+								Type: "text",
+								Text: "No matches found",
+							},
+						},
+						IsError: false,
+					}, nil
+				}
+			} else {
+				cmdArgs := args
+				if input.MaxMatches > 0 {
+					// -m stops reading each file early; the total is capped after the search
+					cmdArgs = append([]string{"-m", strconv.Itoa(input.MaxMatches)}, args...)
+				}
+				cmd := exec.Command("grep", cmdArgs...)
 
-			// Execute the command using the executor
-			output, err := g.cmdExecutor.ExecuteCommand(ctx, cmd)
+				// Execute the command using the executor
+				output, err = g.cmdExecutor.ExecuteCommand(ctx, cmd)
+			}
 
 			// Special handling for grep exit codes
 			if err != nil {
@@ -140,27 +229,11 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 						"stderr":                    errorMsg,
 					}).Error("Grep command execution failed")
 
-					return goai.CallToolResult{
-						Content: []goai.ToolResultContent{
-							{
-								Type: "text",
-								Text: fmt.Sprintf("Grep command failed (exit code %d): %s\nCommand: grep %v",
-									exitError.ExitCode(), errorMsg, args),
-							},
-						},
-						IsError: true,
-					}, nil
+					return returnErrorOutput(upstreamErrorf("Grep command failed (exit code %d): %s\nCommand: grep %v",
+						exitError.ExitCode(), errorMsg, args)), nil
 				}
 				// Handle non-exit errors
-				return goai.CallToolResult{
-					Content: []goai.ToolResultContent{
-						{
-							Type: "text",
-							Text: fmt.Sprintf("Command execution error: %s", err.Error()),
-						},
-					},
-					IsError: true,
-				}, nil
+				return returnErrorOutput(upstreamErrorf("Command execution error: %s", err.Error())), nil
 			}
 
 			g.logger.WithFields(map[string]interface{}{
@@ -168,29 +241,245 @@ func (g *Grep) GrepAllInOneTool() goai.Tool {
 				"output_lenght": len(string(output)),
 			}).Info("Grep command executed successfully")
 
-			return goai.CallToolResult{
-				Content: []goai.ToolResultContent{
-					{
-						Type: "text",
-						Text: string(output),
-					},
-				},
-				IsError: false,
-			}, nil
+			output, truncated := limitGrepOutput(output, input.MaxMatches)
+
+			if input.Structured {
+				matches, err := json.Marshal(parseGrepMatches(string(output)))
+				if err != nil {
+					span.RecordError(err)
+					return returnErrorOutput(newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal matches: %w", err))), nil
+				}
+
+				return grepResult(string(matches), truncated, input.MaxMatches), nil
+			}
+
+			return grepResult(string(output), truncated, input.MaxMatches), nil
 		},
+	})
+}
+
+// grepFileFlags are the short grep flags whose value is a pattern or a file to
+// read; either would let the search escape the validated pattern and path
+const grepFileFlags = "ef"
+
+// grepValueFlags are the short grep flags that take a value, which must be
+// attached to the flag (e.g. -A3) as positional options are rejected
+const grepValueFlags = "ABCDdm"
+
+// grepFileOptions are the long grep options whose value is a pattern or a file to read
+var grepFileOptions = []string{"file", "exclude-from", "regexp"}
+
+// validateGrepOptions rejects options that are not flags, and flags that add
+// patterns, files to read or search paths beyond the validated ones
+func validateGrepOptions(options []string) error {
+	for _, opt := range options {
+		if !strings.HasPrefix(opt, "-") || opt == "-" || opt == "--" {
+			return validationErrorf("invalid option %q: options must be flags, with values attached (e.g. -A3)", opt)
+		}
+
+		if name, ok := strings.CutPrefix(opt, "--"); ok {
+			name, _, _ = strings.Cut(name, "=")
+			for _, fileOption := range grepFileOptions {
+				// grep accepts unambiguous abbreviations of long options;
+				// --exclude is an option of its own, not one of --exclude-from
+				if name != "exclude" && strings.HasPrefix(fileOption, name) {
+					return permissionErrorf("option not allowed: %s", opt)
+				}
+			}
+			continue
+		}
+
+		for _, flag := range opt[1:] {
+			if strings.ContainsRune(grepFileFlags, flag) {
+				return permissionErrorf("option not allowed: %s", opt)
+			}
+			if strings.ContainsRune(grepValueFlags, flag) {
+				// The rest of the option is the flag's value
+				break
+			}
+		}
 	}
+	return nil
 }
 
-func validateGrepInput(input struct {
-	Pattern string   `json:"pattern"`
-	Path    string   `json:"path"`
-	Options []string `json:"options"`
-}) error {
-	if input.Pattern == "" {
-		return fmt.Errorf("pattern is required")
+func validateGrepInput(pattern, path string) error {
+	if pattern == "" {
+		return validationErrorf("pattern is required")
 	}
-	if input.Path == "" {
-		return fmt.Errorf("path is required")
+	if path == "" {
+		return validationErrorf("path is required")
 	}
 	return nil
 }
+
+// limitGrepOutput keeps the first max lines of grep output and reports whether
+// any lines were dropped. A max of zero means unlimited.
+func limitGrepOutput(output []byte, max int) ([]byte, bool) {
+	if max <= 0 {
+		return output, false
+	}
+
+	end := 0
+	for i := 0; i < max; i++ {
+		next := bytes.IndexByte(output[end:], '\n')
+		if next == -1 {
+			return output, false
+		}
+		end += next + 1
+	}
+	if end == len(output) {
+		return output, false
+	}
+	return output[:end], true
+}
+
+// grepResult builds the tool result, appending a note when matches were truncated
+func grepResult(text string, truncated bool, maxMatches int) goai.CallToolResult {
+	content := []goai.ToolResultContent{{Type: "text", Text: text}}
+	if truncated {
+		content = append(content, goai.ToolResultContent{
+			Type: "text",
+			Text: fmt.Sprintf("results truncated: showing the first %d matches", maxMatches),
+		})
+	}
+	return goai.CallToolResult{Content: content, IsError: false}
+}
+
+// GrepMatch represents a single matching line in structured grep output
+type GrepMatch struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// parseGrepMatches parses grep output produced with -n -H ("file:line:text")
+// into structured matches. Lines that don't follow the format are skipped.
+func parseGrepMatches(output string) []GrepMatch {
+	matches := []GrepMatch{}
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		file, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		lineNum, text, ok := strings.Cut(rest, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(lineNum)
+		if err != nil {
+			continue
+		}
+
+		matches = append(matches, GrepMatch{File: file, Line: n, Text: text})
+	}
+	return matches
+}
+
+// searchNative searches path for lines matching pattern using Go's regexp package.
+// The output mirrors grep's text format so it can be handled like the external
+// binary's output. Only the -r, -R, -E, -i, -n, -H and -h options are supported.
+func searchNative(ctx context.Context, pattern, path string, options []string) ([]byte, bool, error) {
+	var ignoreCase, lineNumbers, withFilename, noFilename bool
+	for _, opt := range options {
+		if !strings.HasPrefix(opt, "-") || strings.HasPrefix(opt, "--") || len(opt) < 2 {
+			return nil, false, validationErrorf("unsupported option in native search mode: %s", opt)
+		}
+		for _, flag := range opt[1:] {
+			switch flag {
+			case 'r', 'R', 'E':
+			case 'i':
+				ignoreCase = true
+			case 'n':
+				lineNumbers = true
+			case 'H':
+				withFilename = true
+			case 'h':
+				noFilename = true
+			default:
+				return nil, false, validationErrorf("unsupported option in native search mode: -%c", flag)
+			}
+		}
+	}
+
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, false, newToolError(ErrorKindValidation, fmt.Errorf("invalid pattern: %w", err))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, err
+	}
+	// grep prefixes matches with the file name when searching a directory
+	showFilename := (info.IsDir() || withFilename) && !noFilename
+
+	var out bytes.Buffer
+	matched := false
+	err = filepath.WalkDir(path, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		found, err := searchFile(filePath, re, showFilename, lineNumbers, &out)
+		if err != nil {
+			return err
+		}
+		matched = matched || found
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return out.Bytes(), matched, nil
+}
+
+// searchFile writes the lines of a single file matching re to out, skipping binary files.
+func searchFile(filePath string, re *regexp.Regexp, showFilename, lineNumbers bool, out *bytes.Buffer) (bool, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	if len(content) == 0 {
+		return false, nil
+	}
+
+	head := content
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	if bytes.IndexByte(head, 0) != -1 {
+		return false, nil
+	}
+
+	found := false
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		found = true
+		if showFilename {
+			out.WriteString(filePath + ":")
+		}
+		if lineNumbers {
+			out.WriteString(strconv.Itoa(i+1) + ":")
+		}
+		out.WriteString(line + "\n")
+	}
+	return found, nil
+}
@@ -0,0 +1,321 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGrepMatches(t *testing.T) {
+	output := "main.go:12:func main() {\nnotes/todo.txt:3:time: 10:30\n\ninvalid line\n"
+
+	matches := parseGrepMatches(output)
+
+	require.Len(t, matches, 2)
+	assert.Equal(t, GrepMatch{File: "main.go", Line: 12, Text: "func main() {"}, matches[0])
+	assert.Equal(t, GrepMatch{File: "notes/todo.txt", Line: 3, Text: "time: 10:30"}, matches[1])
+}
+
+func TestGrep_StructuredOutput(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return assert.ObjectsAreEqual([]string{"grep", "-r", "-E", "-n", "-H", "--", "TODO", "src"}, cmd.Args)
+	})).Return([]byte("src/a.go:4:// TODO: fix\nsrc/b.go:27:// TODO: test\n"), nil)
+
+	grep := NewGrep(mockLogger)
+	grep.cmdExecutor = mockExecutor
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"pattern":    "TODO",
+		"path":       "src",
+		"structured": true,
+	})
+	require.NoError(t, err)
+
+	result, err := grep.GrepAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      GrepToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var matches []GrepMatch
+	err = json.Unmarshal([]byte(result.Content[0].Text), &matches)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "src/a.go", matches[0].File)
+	assert.Equal(t, 4, matches[0].Line)
+	assert.Equal(t, 27, matches[1].Line)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestGrep_PathOutsideAllowedDirectory(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	mockExecutor := new(MockCommandExecutor)
+
+	grep := NewGrepWithConfig(mockLogger, GrepConfig{AllowedDirectory: t.TempDir()})
+	grep.cmdExecutor = mockExecutor
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"pattern": "root",
+		"path":    "/etc",
+	})
+	require.NoError(t, err)
+
+	result, err := grep.GrepAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      GrepToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "path outside allowed directory")
+	mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+}
+
+func TestGrep_NativeSearch(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("Hello World\nfoo bar\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("nothing here\nhello again\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "binary.bin"), []byte("hello\x00world"), 0644))
+
+	fileA := filepath.Join(dir, "a.txt")
+	fileB := filepath.Join(dir, "sub", "b.txt")
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+		isError  bool
+	}{
+		{
+			name:     "case-sensitive directory search",
+			input:    map[string]interface{}{"pattern": "hello", "path": dir},
+			expected: fileB + ":hello again\n",
+		},
+		{
+			name:     "case-insensitive directory search",
+			input:    map[string]interface{}{"pattern": "hello", "path": dir, "options": []string{"-i"}},
+			expected: fileA + ":Hello World\n" + fileB + ":hello again\n",
+		},
+		{
+			name:     "single file with line numbers",
+			input:    map[string]interface{}{"pattern": "fo+", "path": fileA, "options": []string{"-n"}},
+			expected: "2:foo bar\n",
+		},
+		{
+			name:     "structured output",
+			input:    map[string]interface{}{"pattern": "again", "path": dir, "structured": true},
+			expected: `[{"file":"` + fileB + `","line":2,"text":"hello again"}]`,
+		},
+		{
+			name:     "no matches",
+			input:    map[string]interface{}{"pattern": "missing", "path": dir},
+			expected: "No matches found",
+		},
+		{
+			name:     "unsupported option",
+			input:    map[string]interface{}{"pattern": "hello", "path": dir, "options": []string{"-v"}},
+			expected: "unsupported option in native search mode: -v",
+			isError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			grep := NewGrepWithConfig(mockLogger, GrepConfig{UseNativeSearch: true})
+
+			inputJSON, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+
+			result, err := grep.GrepAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GrepToolName,
+				Arguments: inputJSON,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.isError, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
+
+func TestGrep_MaxMatches(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return assert.ObjectsAreEqual([]string{"grep", "-m", "2", "-r", "-E", "-n", "-H", "--", "TODO", "src"}, cmd.Args)
+	})).Return([]byte("src/a.go:4:// TODO: fix\nsrc/a.go:9:// TODO: doc\nsrc/b.go:27:// TODO: test\n"), nil)
+
+	grep := NewGrep(mockLogger)
+	grep.cmdExecutor = mockExecutor
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"pattern":     "TODO",
+		"path":        "src",
+		"structured":  true,
+		"max_matches": 2,
+	})
+	require.NoError(t, err)
+
+	result, err := grep.GrepAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      GrepToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+
+	var matches []GrepMatch
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &matches))
+	require.Len(t, matches, 2)
+	assert.Equal(t, 9, matches[1].Line)
+	assert.Equal(t, "results truncated: showing the first 2 matches", result.Content[1].Text)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestGrep_NativeSearchMaxMatches(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("match 1\nmatch 2\nmatch 3\n"), 0644))
+
+	grep := NewGrepWithConfig(mockLogger, GrepConfig{UseNativeSearch: true})
+
+	for _, tt := range []struct {
+		name       string
+		maxMatches int
+		expected   string
+		truncated  bool
+	}{
+		{name: "truncated", maxMatches: 2, expected: "match 1\nmatch 2\n", truncated: true},
+		{name: "limit not reached", maxMatches: 3, expected: "match 1\nmatch 2\nmatch 3\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"pattern":     "match",
+				"path":        file,
+				"max_matches": tt.maxMatches,
+			})
+			require.NoError(t, err)
+
+			result, err := grep.GrepAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GrepToolName,
+				Arguments: inputJSON,
+			})
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+			assert.Equal(t, tt.truncated, len(result.Content) == 2)
+		})
+	}
+}
+
+func TestGrep_OptionValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       []string
+		expectedError string
+	}{
+		{
+			name:          "extra search path",
+			options:       []string{"-e", "x", "/etc/passwd"},
+			expectedError: "option not allowed: -e",
+		},
+		{
+			name:          "positional option",
+			options:       []string{"/etc/passwd"},
+			expectedError: `invalid option "/etc/passwd": options must be flags`,
+		},
+		{
+			name:          "pattern file",
+			options:       []string{"-f/etc/passwd"},
+			expectedError: "option not allowed: -f/etc/passwd",
+		},
+		{
+			name:          "pattern file in a flag group",
+			options:       []string{"-if/etc/passwd"},
+			expectedError: "option not allowed: -if/etc/passwd",
+		},
+		{
+			name:          "long pattern file",
+			options:       []string{"--file=/etc/passwd"},
+			expectedError: "option not allowed: --file=/etc/passwd",
+		},
+		{
+			name:          "abbreviated long pattern file",
+			options:       []string{"--fil=/etc/passwd"},
+			expectedError: "option not allowed: --fil=/etc/passwd",
+		},
+		{
+			name:          "exclude patterns file",
+			options:       []string{"--exclude-from=/etc/passwd"},
+			expectedError: "option not allowed: --exclude-from=/etc/passwd",
+		},
+		{
+			name:          "end of options",
+			options:       []string{"--", "/etc/passwd"},
+			expectedError: `invalid option "--"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+			mockLogger.On("Info", mock.Anything).Return()
+			mockLogger.On("Error", mock.Anything).Return()
+
+			mockExecutor := new(MockCommandExecutor)
+
+			grep := NewGrepWithConfig(mockLogger, GrepConfig{AllowedDirectory: t.TempDir()})
+			grep.cmdExecutor = mockExecutor
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"pattern": "root",
+				"path":    grep.config.AllowedDirectory,
+				"options": tt.options,
+			})
+			require.NoError(t, err)
+
+			result, err := grep.GrepAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      GrepToolName,
+				Arguments: inputJSON,
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestValidateGrepOptions_AllowsFlags(t *testing.T) {
+	assert.NoError(t, validateGrepOptions([]string{"-i", "-rn", "-A3", "-m5", "--exclude=*.log", "--include=*.go", "--exclude-dir=vendor", "--ignore-case"}))
+}
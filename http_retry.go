@@ -0,0 +1,187 @@
+package mcptools
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls how retryDo and the GitHub tools retry failed requests
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 1 are treated as 1, i.e. no retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on every further retry
+	BaseDelay time.Duration
+	// Jitter is the upper bound of a random duration added to every wait, so
+	// concurrent clients do not retry in lockstep
+	Jitter time.Duration
+	// MaxDelay caps the backoff between attempts. A server asking for a longer
+	// wait, e.g. with a Retry-After header, ends the retries instead, so it
+	// cannot stall a call indefinitely. Zero means defaultRetryMaxDelay.
+	MaxDelay time.Duration
+	// RetryableStatusCodes lists the response codes worth retrying. When empty,
+	// 429 Too Many Requests and every 5xx code are retried.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent also retries methods such as POST and PATCH, which may
+	// apply their effect twice if a failed attempt did reach the server
+	RetryNonIdempotent bool
+}
+
+// defaultRetryMaxDelay is the longest wait between attempts when
+// RetryConfig.MaxDelay is not set
+const defaultRetryMaxDelay = time.Minute
+
+// idempotentMethods lists the HTTP methods that are safe to send more than once
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryDo sends the request built by newRequest, retrying idempotent requests,
+// or every request when RetryNonIdempotent is set, on network errors and
+// retryable status codes as described by retryLoop. A fresh request is built
+// for every attempt so that request bodies can be replayed. The Retry-After
+// header of a retryable response is the wait the server asks for. When the
+// retries end, the last response or error is returned.
+func retryDo(ctx context.Context, client *http.Client, newRequest func(ctx context.Context) (*http.Request, error), config RetryConfig) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	loopErr := retryLoop(ctx, config, func() (bool, *time.Duration) {
+		var req *http.Request
+		req, err = newRequest(ctx)
+		if err != nil {
+			resp = nil
+			return false, nil
+		}
+
+		resp, err = client.Do(req)
+		if !idempotentMethods[req.Method] && !config.RetryNonIdempotent {
+			return false, nil
+		}
+		if err != nil {
+			return ctx.Err() == nil, nil
+		}
+		if !config.isRetryableStatus(resp.StatusCode) {
+			return false, nil
+		}
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return true, &retryAfter
+		}
+		return true, nil
+	}, func(int, time.Duration) {
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	})
+	if loopErr != nil {
+		return nil, loopErr
+	}
+	return resp, err
+}
+
+// retryLoop calls try until it reports that its outcome is final or the
+// attempts are used up, waiting between attempts with exponential backoff
+// capped at MaxDelay. try may return the wait the server asked for, which
+// takes precedence over the backoff; when it exceeds MaxDelay or outlasts the
+// context deadline, retrying sooner would fail again, so the retries end.
+// beforeWait, if not nil, runs before every wait. The outcome of the last
+// attempt is left to try; retryLoop only returns the context error if the
+// context ends while waiting.
+func retryLoop(ctx context.Context, config RetryConfig, try func() (retryable bool, retryAfter *time.Duration), beforeWait func(attempt int, wait time.Duration)) error {
+	attempts := config.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	maxDelay := config.maxDelay()
+
+	for attempt := 1; ; attempt++ {
+		retryable, retryAfter := try()
+		if !retryable || attempt >= attempts {
+			return nil
+		}
+
+		wait := config.backoff(attempt)
+		if wait > maxDelay || wait < 0 {
+			wait = maxDelay
+		}
+		if retryAfter != nil {
+			wait = *retryAfter
+			if wait > maxDelay {
+				return nil
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return nil
+			}
+		}
+
+		if beforeWait != nil {
+			beforeWait(attempt, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryableStatus reports whether a response with the given status code should be retried
+func (c RetryConfig) isRetryableStatus(code int) bool {
+	if len(c.RetryableStatusCodes) == 0 {
+		return code == http.StatusTooManyRequests || code >= 500
+	}
+	for _, retryable := range c.RetryableStatusCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the retry following the given attempt
+func (c RetryConfig) backoff(attempt int) time.Duration {
+	wait := c.BaseDelay << (attempt - 1)
+	if c.Jitter > 0 {
+		wait += time.Duration(rand.Int63n(int64(c.Jitter))) // #nosec G404 -- jitter does not need a secure source
+	}
+	return wait
+}
+
+// maxDelay returns the longest wait between attempts
+func (c RetryConfig) maxDelay() time.Duration {
+	if c.MaxDelay <= 0 {
+		return defaultRetryMaxDelay
+	}
+	return c.MaxDelay
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,86 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/xeipuuv/gojsonschema"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// withInputValidation wraps the tool handler so the arguments are validated
+// against the tool's InputSchema before the handler runs. Rejected inputs are
+// recorded on the handler span and logged when logger is not nil. Arguments
+// that are not valid JSON are passed through, so the handler reports its usual
+// parse error. It panics if the schema cannot be compiled, as the schemas are
+// fixed at build time.
+func withInputValidation(logger goai.Logger, tool goai.Tool) goai.Tool {
+	if len(tool.InputSchema) == 0 || tool.Handler == nil {
+		return tool
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(tool.InputSchema))
+	if err != nil {
+		panic(fmt.Sprintf("invalid input schema for tool %s: %v", tool.Name, err))
+	}
+
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+		if err := validateToolInput(schema, params.Arguments); err != nil {
+			_, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			span.RecordError(err)
+			span.End()
+
+			if logger != nil {
+				logger.WithFields(map[string]interface{}{
+					"tool_name":        params.Name,
+					goai.ErrorLogField: err,
+				}).Error("Input validation failed")
+			}
+			return returnErrorOutput(err), nil
+		}
+		return handler(ctx, params)
+	}
+	return tool
+}
+
+// validateToolInput returns a validation error listing every field of args
+// that does not satisfy schema. Top-level null values are treated as absent,
+// as the handlers decode them to the field's zero value.
+func validateToolInput(schema *gojsonschema.Schema, args []byte) error {
+	var input interface{} = map[string]interface{}{}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &input); err != nil {
+			return nil
+		}
+	}
+	if fields, ok := input.(map[string]interface{}); ok {
+		for name, value := range fields {
+			if value == nil {
+				delete(fields, name)
+			}
+		}
+	}
+
+	result, err := schema.Validate(gojsonschema.NewGoLoader(input))
+	if err != nil || result.Valid() {
+		return nil
+	}
+
+	problems := make([]string, 0, len(result.Errors()))
+	for _, resultErr := range result.Errors() {
+		if resultErr.Field() == gojsonschema.STRING_CONTEXT_ROOT {
+			problems = append(problems, resultErr.Description())
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s: %s", resultErr.Field(), resultErr.Description()))
+	}
+	return validationErrorf("invalid input: %s", strings.Join(problems, "; "))
+}
@@ -0,0 +1,165 @@
+package mcptools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestInputValidation_MissingRequiredField(t *testing.T) {
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything).Return().Maybe()
+
+	tests := []struct {
+		name          string
+		tool          goai.Tool
+		arguments     string
+		expectedError string
+	}{
+		{
+			name:          "postgresql without operation",
+			tool:          NewPostgreSQL(logger, PostgreSQLConfig{}).PostgreSQLAllInOneTool(),
+			arguments:     `{"database": "app"}`,
+			expectedError: "invalid input: operation is required",
+		},
+		{
+			name:          "filesystem without path",
+			tool:          NewFileSystem(logger, FileSystemConfig{AllowedDirectory: t.TempDir()}).FileSystemAllInOneTool(),
+			arguments:     `{"operation": "list"}`,
+			expectedError: "invalid input: path is required",
+		},
+		{
+			name:          "curl with a field of the wrong type",
+			tool:          NewCurl(logger, CurlConfig{}).CurlAllInOneTool(),
+			arguments:     `{"url": "https://example.com", "method": 42}`,
+			expectedError: "invalid input: method: Invalid type. Expected: string, given: integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.tool.Handler(context.Background(), goai.CallToolParams{
+				Name:      tt.tool.Name,
+				Arguments: []byte(tt.arguments),
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expectedError, result.Content[0].Text)
+
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, ErrorKindValidation, kind)
+		})
+	}
+}
+
+func TestInputValidation_NullTreatedAsAbsent(t *testing.T) {
+	tool := withInputValidation(nil, goai.Tool{
+		Name: "echo",
+		InputSchema: []byte(`{
+			"type": "object",
+			"properties": {
+				"text": {"type": "string"},
+				"tags": {"type": "array", "items": {"type": "string"}}
+			},
+			"required": ["text"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{Type: "text", Text: "ok"}},
+			}, nil
+		},
+	})
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{
+		Name:      "echo",
+		Arguments: []byte(`{"text": "hi", "tags": null}`),
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	result, err = tool.Handler(context.Background(), goai.CallToolParams{
+		Name:      "echo",
+		Arguments: []byte(`{"text": null}`),
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "invalid input: text is required", result.Content[0].Text)
+}
+
+func TestInputValidation_AllToolSchemasCompile(t *testing.T) {
+	logger := new(MockLogger)
+	github := NewGitHubTool(logger, GitHubConfig{})
+
+	tools := []goai.Tool{
+		NewBash(logger).BashAllInOneTool(),
+		NewCat(logger).CatAllInOneTool(),
+		NewCurl(logger, CurlConfig{}).CurlAllInOneTool(),
+		NewDocker(logger).DockerAllInOneTool(),
+		NewFileSystem(logger, FileSystemConfig{}).FileSystemAllInOneTool(),
+		NewGit(logger, GitConfig{}).GitAllInOneTool(),
+		github.GetGistsTool(),
+		github.GetIssuesTool(),
+		github.GetLabelsTool(),
+		github.GetPullRequestsTool(),
+		github.GetRateLimitTool(),
+		github.GetRepositoryTool(),
+		github.GetSearchTool(),
+		NewGmail(logger, nil, GmailConfig{}).GmailAllInOneTool(),
+		NewGrep(logger).GrepAllInOneTool(),
+		NewKubernetes(logger, KubeConfig{}).KubernetesAllInOneTool(),
+		NewPostgreSQL(logger, PostgreSQLConfig{}).PostgreSQLAllInOneTool(),
+		NewSed(logger).SedAllInOneTool(),
+		NewSlack(logger, SlackConfig{}).SlackAllInOneTool(),
+		NewSQLite(logger, SQLiteConfig{}).SQLiteAllInOneTool(),
+		GetWeather,
+	}
+
+	for _, tool := range tools {
+		t.Run(tool.Name, func(t *testing.T) {
+			_, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(tool.InputSchema))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInputValidation_InvalidSchemaPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		withInputValidation(nil, goai.Tool{
+			Name:        "broken",
+			InputSchema: []byte(`{"type": "nope"}`),
+			Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+				return goai.CallToolResult{}, nil
+			},
+		})
+	})
+}
+
+func TestInputValidation_RejectionIsRecorded(t *testing.T) {
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		return fields["tool_name"] == SQLiteToolName && fields[goai.ErrorLogField] != nil
+	})).Return(logger)
+	logger.On("Error", []interface{}{"Input validation failed"}).Return()
+
+	ctx, recorder := newTracingTestContext(t)
+	tool := NewSQLite(logger, SQLiteConfig{}).SQLiteAllInOneTool()
+
+	result, err := tool.Handler(ctx, goai.CallToolParams{
+		Name:      SQLiteToolName,
+		Arguments: []byte(`{"operation": "list_tables"}`),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.True(t, spanRecordedError(requireHandlerSpan(t, recorder, SQLiteToolName)))
+	logger.AssertExpectations(t)
+}
@@ -0,0 +1,512 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// KubernetesToolName is the name of the Kubernetes tool
+	KubernetesToolName = "kubernetes"
+
+	// kubernetesDefaultTailLines is the number of log lines returned when tail_lines is omitted
+	kubernetesDefaultTailLines = 100
+)
+
+// Kubernetes represents a read-only wrapper around the Kubernetes API
+type Kubernetes struct {
+	logger goai.Logger
+	config KubeConfig
+
+	clientOnce sync.Once
+	client     kubernetes.Interface
+	clientErr  error
+}
+
+// KubeConfig holds the configuration for the Kubernetes tool
+type KubeConfig struct {
+	// Kubeconfig is the path of the kubeconfig file. When empty, the KUBECONFIG
+	// environment variable, ~/.kube/config and the in-cluster configuration are
+	// tried in that order.
+	Kubeconfig string
+	// Namespace is used when the input omits one. Empty means "default".
+	Namespace string
+	// AllowMutations lets the API client send requests other than GET and
+	// HEAD. By default they are refused, so nothing can be changed in the
+	// cluster even if the credentials allow it. The tool itself only offers
+	// read operations.
+	AllowMutations bool
+}
+
+// NewKubernetes creates a new Kubernetes tool. The API client is created on
+// first use, so a missing kubeconfig is reported by the tool call.
+func NewKubernetes(logger goai.Logger, config KubeConfig) *Kubernetes {
+	if config.Namespace == "" {
+		config.Namespace = "default"
+	}
+
+	return &Kubernetes{
+		logger: logger,
+		config: config,
+	}
+}
+
+// KubernetesAllInOneTool returns a goai.Tool that can perform read operations on a Kubernetes cluster
+func (k *Kubernetes) KubernetesAllInOneTool() goai.Tool {
+	return withInputValidation(k.logger, goai.Tool{
+		Name:        KubernetesToolName,
+		Description: "Reads Kubernetes resources: lists pods, deployments and services, describes a resource with its events and fetches pod logs",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"description": "Kubernetes operation to execute",
+					"enum": ["get_pods", "get_deployments", "get_services", "describe", "logs"]
+				},
+				"namespace": {
+					"type": "string",
+					"description": "Namespace of the resources (defaults to the configured namespace)"
+				},
+				"label_selector": {
+					"type": "string",
+					"description": "Label selector to filter get_pods, get_deployments and get_services results, e.g. app=web"
+				},
+				"kind": {
+					"type": "string",
+					"description": "Kind of the resource to describe",
+					"enum": ["pod", "deployment", "service"]
+				},
+				"name": {
+					"type": "string",
+					"description": "Name of the resource for describe, or of the pod for logs"
+				},
+				"container": {
+					"type": "string",
+					"description": "Container to read logs from (for logs operation). Required for pods with several containers"
+				},
+				"tail_lines": {
+					"type": "integer",
+					"description": "Number of most recent log lines to return (for logs operation, default: 100)",
+					"minimum": 1
+				},
+				"previous": {
+					"type": "boolean",
+					"description": "Return the logs of the previous, terminated container instance (for logs operation)"
+				}
+			},
+			"required": ["operation"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			k.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Starting Kubernetes operation execution")
+
+			var input struct {
+				Operation     string `json:"operation"`
+				Namespace     string `json:"namespace,omitempty"`
+				LabelSelector string `json:"label_selector,omitempty"`
+				Kind          string `json:"kind,omitempty"`
+				Name          string `json:"name,omitempty"`
+				Container     string `json:"container,omitempty"`
+				TailLines     int64  `json:"tail_lines,omitempty"`
+				Previous      bool   `json:"previous,omitempty"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				k.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"raw_input":        string(params.Arguments),
+				}).Error("Failed to unmarshal input parameters")
+
+				span.RecordError(err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+			}
+
+			namespace := input.Namespace
+			if namespace == "" {
+				namespace = k.config.Namespace
+			}
+
+			var result interface{}
+			client, err := k.clientset()
+			if err == nil {
+				listOptions := metav1.ListOptions{LabelSelector: input.LabelSelector}
+				switch input.Operation {
+				case "get_pods":
+					result, err = k.getPods(ctx, client, namespace, listOptions)
+				case "get_deployments":
+					result, err = k.getDeployments(ctx, client, namespace, listOptions)
+				case "get_services":
+					result, err = k.getServices(ctx, client, namespace, listOptions)
+				case "describe":
+					result, err = k.describe(ctx, client, namespace, input.Kind, input.Name)
+				case "logs":
+					result, err = k.logs(ctx, client, namespace, input.Name, input.Container, input.TailLines, input.Previous)
+				default:
+					err = validationErrorf("unsupported operation: %s", input.Operation)
+				}
+			}
+
+			var output []byte
+			if err == nil {
+				output, err = json.Marshal(result)
+			}
+
+			if err != nil {
+				err = kubernetesToolError(err)
+				k.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"operation":        input.Operation,
+					"namespace":        namespace,
+				}).Error("Kubernetes operation failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			k.logger.WithFields(map[string]interface{}{
+				"tool":          KubernetesToolName,
+				"operation":     input.Operation,
+				"result_length": len(output),
+			}).Debug("Kubernetes operation completed successfully")
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "text",
+					Text: string(output),
+				}},
+			}, nil
+		},
+	})
+}
+
+// clientset returns the API client, creating it from the kubeconfig on first use
+func (k *Kubernetes) clientset() (kubernetes.Interface, error) {
+	k.clientOnce.Do(func() {
+		if k.client != nil {
+			return
+		}
+
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.ExplicitPath = k.config.Kubeconfig
+		restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+		if err != nil {
+			k.clientErr = fmt.Errorf("failed to load kubeconfig: %w", err)
+			return
+		}
+		if !k.config.AllowMutations {
+			restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+				return readOnlyRoundTripper{next: rt}
+			})
+		}
+
+		k.client, k.clientErr = kubernetes.NewForConfig(restConfig)
+	})
+	return k.client, k.clientErr
+}
+
+// readOnlyRoundTripper rejects every request that could change the cluster
+type readOnlyRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt readOnlyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, permissionErrorf("%s %s is not allowed: the Kubernetes client is read-only", req.Method, req.URL.Path)
+	}
+	return rt.next.RoundTrip(req)
+}
+
+// kubernetesToolError classifies a failed Kubernetes API call by its status
+func kubernetesToolError(err error) error {
+	switch {
+	case apierrors.IsNotFound(err):
+		return newToolError(ErrorKindNotFound, err)
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return newToolError(ErrorKindPermission, err)
+	}
+	return newToolError(ErrorKindUpstream, err)
+}
+
+// kubernetesPod is the summary of a pod returned by get_pods
+type kubernetesPod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	Ready     string `json:"ready"`
+	Restarts  int32  `json:"restarts"`
+	Node      string `json:"node,omitempty"`
+	PodIP     string `json:"pod_ip,omitempty"`
+	Created   string `json:"created"`
+}
+
+func (k *Kubernetes) getPods(ctx context.Context, client kubernetes.Interface, namespace string, options metav1.ListOptions) (interface{}, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	result := make([]kubernetesPod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		var ready int
+		var restarts int32
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				ready++
+			}
+			restarts += status.RestartCount
+		}
+
+		result = append(result, kubernetesPod{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Phase:     string(pod.Status.Phase),
+			Ready:     fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)),
+			Restarts:  restarts,
+			Node:      pod.Spec.NodeName,
+			PodIP:     pod.Status.PodIP,
+			Created:   formatKubernetesTime(pod.CreationTimestamp),
+		})
+	}
+	return result, nil
+}
+
+// kubernetesDeployment is the summary of a deployment returned by get_deployments
+type kubernetesDeployment struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Replicas  int32    `json:"replicas"`
+	Ready     int32    `json:"ready"`
+	Updated   int32    `json:"updated"`
+	Available int32    `json:"available"`
+	Images    []string `json:"images"`
+	Created   string   `json:"created"`
+}
+
+func (k *Kubernetes) getDeployments(ctx context.Context, client kubernetes.Interface, namespace string, options metav1.ListOptions) (interface{}, error) {
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	result := make([]kubernetesDeployment, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		var replicas int32 = 1
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+
+		images := make([]string, 0, len(deployment.Spec.Template.Spec.Containers))
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+
+		result = append(result, kubernetesDeployment{
+			Name:      deployment.Name,
+			Namespace: deployment.Namespace,
+			Replicas:  replicas,
+			Ready:     deployment.Status.ReadyReplicas,
+			Updated:   deployment.Status.UpdatedReplicas,
+			Available: deployment.Status.AvailableReplicas,
+			Images:    images,
+			Created:   formatKubernetesTime(deployment.CreationTimestamp),
+		})
+	}
+	return result, nil
+}
+
+// kubernetesService is the summary of a service returned by get_services
+type kubernetesService struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Type       string            `json:"type"`
+	ClusterIP  string            `json:"cluster_ip,omitempty"`
+	ExternalIP []string          `json:"external_ips,omitempty"`
+	Ports      []string          `json:"ports"`
+	Selector   map[string]string `json:"selector,omitempty"`
+	Created    string            `json:"created"`
+}
+
+func (k *Kubernetes) getServices(ctx context.Context, client kubernetes.Interface, namespace string, options metav1.ListOptions) (interface{}, error) {
+	services, err := client.CoreV1().Services(namespace).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	result := make([]kubernetesService, 0, len(services.Items))
+	for _, service := range services.Items {
+		ports := make([]string, 0, len(service.Spec.Ports))
+		for _, port := range service.Spec.Ports {
+			ports = append(ports, formatServicePort(port))
+		}
+
+		externalIPs := append([]string{}, service.Spec.ExternalIPs...)
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				externalIPs = append(externalIPs, ingress.IP)
+			} else if ingress.Hostname != "" {
+				externalIPs = append(externalIPs, ingress.Hostname)
+			}
+		}
+
+		result = append(result, kubernetesService{
+			Name:       service.Name,
+			Namespace:  service.Namespace,
+			Type:       string(service.Spec.Type),
+			ClusterIP:  service.Spec.ClusterIP,
+			ExternalIP: externalIPs,
+			Ports:      ports,
+			Selector:   service.Spec.Selector,
+			Created:    formatKubernetesTime(service.CreationTimestamp),
+		})
+	}
+	return result, nil
+}
+
+// formatServicePort renders a service port like kubectl, e.g. 80:30080/TCP
+func formatServicePort(port corev1.ServicePort) string {
+	if port.NodePort != 0 {
+		return fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol)
+	}
+	return fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+}
+
+// kubernetesEvent is an event about a described resource
+type kubernetesEvent struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Count   int32  `json:"count,omitempty"`
+	Last    string `json:"last_seen,omitempty"`
+}
+
+// describe returns the full resource, without its managed fields, along with
+// the events recorded for it
+func (k *Kubernetes) describe(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) (interface{}, error) {
+	if kind == "" || name == "" {
+		return nil, validationErrorf("kind and name are required for describe operation")
+	}
+
+	var object interface{}
+	var err error
+	switch kind {
+	case "pod":
+		pod, getErr := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			pod.ManagedFields = nil
+			object = pod
+		}
+	case "deployment":
+		deployment, getErr := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			deployment.ManagedFields = nil
+			object = deployment
+		}
+	case "service":
+		service, getErr := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			service.ManagedFields = nil
+			object = service
+		}
+	default:
+		return nil, validationErrorf("unsupported kind: %s (allowed: pod, deployment, service)", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+	}
+
+	selector := fields.Set{
+		"involvedObject.name": name,
+		"involvedObject.kind": kubernetesKinds[kind],
+	}.AsSelector().String()
+	eventList, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %s %s: %w", kind, name, err)
+	}
+
+	events := make([]kubernetesEvent, 0, len(eventList.Items))
+	for _, event := range eventList.Items {
+		events = append(events, kubernetesEvent{
+			Type:    event.Type,
+			Reason:  event.Reason,
+			Message: event.Message,
+			Count:   event.Count,
+			Last:    formatKubernetesTime(event.LastTimestamp),
+		})
+	}
+
+	return map[string]interface{}{
+		"object": object,
+		"events": events,
+	}, nil
+}
+
+// kubernetesKinds maps the kind input to the kind recorded in events
+var kubernetesKinds = map[string]string{
+	"pod":        "Pod",
+	"deployment": "Deployment",
+	"service":    "Service",
+}
+
+// logs returns the most recent log lines of a pod container
+func (k *Kubernetes) logs(ctx context.Context, client kubernetes.Interface, namespace, pod, container string, tailLines int64, previous bool) (interface{}, error) {
+	if pod == "" {
+		return nil, validationErrorf("name is required for logs operation")
+	}
+	if tailLines <= 0 {
+		tailLines = kubernetesDefaultTailLines
+	}
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &tailLines,
+		Previous:  previous,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs of pod %s: %w", pod, err)
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs of pod %s: %w", pod, err)
+	}
+
+	return map[string]interface{}{
+		"pod":       pod,
+		"container": container,
+		"logs":      strings.TrimRight(string(logs), "\n"),
+	}, nil
+}
+
+// formatKubernetesTime formats a timestamp as RFC3339, or returns an empty string when unset
+func formatKubernetesTime(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
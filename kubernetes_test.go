@@ -0,0 +1,312 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func setupKubernetesTest(t *testing.T, config KubeConfig) *Kubernetes {
+	created := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	replicas := int32(3)
+
+	client := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "web-1",
+				Namespace:         "shop",
+				Labels:            map[string]string{"app": "web"},
+				CreationTimestamp: created,
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   "node-a",
+				Containers: []corev1.Container{{Name: "web", Image: "nginx:1.25"}, {Name: "sidecar", Image: "envoy:1.29"}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: "10.0.0.12",
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "web", Ready: true, RestartCount: 2},
+					{Name: "sidecar", Ready: false, RestartCount: 1},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "shop", Labels: map[string]string{"app": "worker"}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-1", Namespace: "default"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: created},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx:1.25"}}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 2},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: created},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeNodePort,
+				ClusterIP: "10.96.0.10",
+				Selector:  map[string]string{"app": "web"},
+				Ports:     []corev1.ServicePort{{Port: 80, NodePort: 30080, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-1.backoff", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "shop"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container sidecar",
+			Count:          4,
+		},
+	)
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+	logger.On("Debug", mock.Anything).Return()
+	logger.On("Error", mock.Anything).Return().Maybe()
+
+	k := NewKubernetes(logger, config)
+	k.client = client
+	return k
+}
+
+func callKubernetesTool(t *testing.T, k *Kubernetes, input map[string]interface{}) goai.CallToolResult {
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := k.KubernetesAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      KubernetesToolName,
+		Arguments: inputBytes,
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestKubernetes_GetPods(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{Namespace: "shop"})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{
+		"operation":      "get_pods",
+		"label_selector": "app=web",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var pods []kubernetesPod
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &pods))
+	assert.Equal(t, []kubernetesPod{{
+		Name:      "web-1",
+		Namespace: "shop",
+		Phase:     "Running",
+		Ready:     "1/2",
+		Restarts:  3,
+		Node:      "node-a",
+		PodIP:     "10.0.0.12",
+		Created:   "2024-01-01T10:00:00Z",
+	}}, pods)
+}
+
+func TestKubernetes_GetPodsDefaultNamespace(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{"operation": "get_pods"})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var pods []kubernetesPod
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &pods))
+	require.Len(t, pods, 1)
+	assert.Equal(t, "other-1", pods[0].Name)
+}
+
+func TestKubernetes_GetDeploymentsAndServices(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{
+		"operation": "get_deployments",
+		"namespace": "shop",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var deployments []kubernetesDeployment
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &deployments))
+	require.Len(t, deployments, 1)
+	assert.Equal(t, int32(3), deployments[0].Replicas)
+	assert.Equal(t, int32(2), deployments[0].Ready)
+	assert.Equal(t, []string{"nginx:1.25"}, deployments[0].Images)
+
+	result = callKubernetesTool(t, k, map[string]interface{}{
+		"operation": "get_services",
+		"namespace": "shop",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var services []kubernetesService
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &services))
+	require.Len(t, services, 1)
+	assert.Equal(t, "NodePort", services[0].Type)
+	assert.Equal(t, []string{"80:30080/TCP"}, services[0].Ports)
+	assert.Equal(t, map[string]string{"app": "web"}, services[0].Selector)
+}
+
+func TestKubernetes_Describe(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{Namespace: "shop"})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{
+		"operation": "describe",
+		"kind":      "pod",
+		"name":      "web-1",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var output struct {
+		Object corev1.Pod        `json:"object"`
+		Events []kubernetesEvent `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &output))
+	assert.Equal(t, "web-1", output.Object.Name)
+	assert.Equal(t, "node-a", output.Object.Spec.NodeName)
+	require.Len(t, output.Events, 1)
+	assert.Equal(t, "BackOff", output.Events[0].Reason)
+	assert.Equal(t, int32(4), output.Events[0].Count)
+}
+
+func TestKubernetes_DescribeNotFound(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{Namespace: "shop"})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{
+		"operation": "describe",
+		"kind":      "deployment",
+		"name":      "missing",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "failed to get deployment missing")
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindNotFound, kind)
+}
+
+func TestKubernetes_Logs(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{Namespace: "shop"})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{
+		"operation":  "logs",
+		"name":       "web-1",
+		"container":  "web",
+		"tail_lines": 20,
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var output map[string]string
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &output))
+	assert.Equal(t, "web-1", output["pod"])
+	assert.Equal(t, "web", output["container"])
+	// The fake clientset always serves this body
+	assert.Equal(t, "fake logs", output["logs"])
+}
+
+func TestKubernetes_LogsRequiresName(t *testing.T) {
+	k := setupKubernetesTest(t, KubeConfig{})
+
+	result := callKubernetesTool(t, k, map[string]interface{}{"operation": "logs"})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "name is required for logs operation")
+}
+
+func TestReadOnlyRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: readOnlyRoundTripper{next: http.DefaultTransport}}
+
+	resp, err := client.Get(server.URL + "/api/v1/namespaces/default/pods")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL+"/api/v1/namespaces/default/pods/web-1", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+		require.Error(t, err, method)
+		assert.Contains(t, err.Error(), "the Kubernetes client is read-only")
+
+		kind, ok := ErrorKindOf(err)
+		require.True(t, ok)
+		assert.Equal(t, ErrorKindPermission, kind)
+	}
+}
+
+func TestNewKubernetes_ReadOnlyByDefault(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+	}))
+	defer server.Close()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: `+server.URL+`
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`), 0600))
+
+	logger := new(MockLogger)
+
+	t.Run("mutations are refused by default", func(t *testing.T) {
+		requests = nil
+		client, err := NewKubernetes(logger, KubeConfig{Kubeconfig: kubeconfig}).clientset()
+		require.NoError(t, err)
+
+		err = client.CoreV1().Pods("default").Delete(context.Background(), "web-1", metav1.DeleteOptions{})
+		require.Error(t, err)
+		kind, ok := ErrorKindOf(err)
+		require.True(t, ok)
+		assert.Equal(t, ErrorKindPermission, kind)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("mutations are sent when allowed", func(t *testing.T) {
+		requests = nil
+		client, err := NewKubernetes(logger, KubeConfig{Kubeconfig: kubeconfig, AllowMutations: true}).clientset()
+		require.NoError(t, err)
+
+		err = client.CoreV1().Pods("default").Delete(context.Background(), "web-1", metav1.DeleteOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{http.MethodDelete}, requests)
+	})
+}
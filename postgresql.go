@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +19,13 @@ import (
 // PostgreSQLToolName is the name of the PostgreSQL tool
 const PostgreSQLToolName = "postgresql"
 
+// databaseIdentifierPattern matches the database identifiers that can be
+// derived from environment variable prefixes
+var databaseIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// noRowsReturnedMessage is the output of a query that has no result set
+const noRowsReturnedMessage = "statement executed, no rows returned"
+
 // PostgreSQL represents a tool for performing PostgreSQL operations
 type PostgreSQL struct {
 	logger   goai.Logger
@@ -54,16 +63,16 @@ func NewPostgreSQL(logger goai.Logger, config PostgreSQLConfig) *PostgreSQL {
 
 // PostgreSQLAllInOneTool remains mostly the same, but uses getConnection instead
 func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(p.logger, goai.Tool{
 		Name:        PostgreSQLToolName,
-		Description: "Performs PostgreSQL operations including querying, explaining queries, and retrieving schema information",
+		Description: "Performs PostgreSQL operations including querying, explaining queries, retrieving schema information, and reporting connection pool statistics",
 		InputSchema: json.RawMessage(`{
             "type": "object",
             "properties": {
                 "operation": {
                     "type": "string",
-                    "description": "Operation to perform (query, explain, schema, list_databases)",
-                    "enum": ["query", "explain", "schema", "list_databases"]
+                    "description": "Operation to perform (query, explain, schema, list_databases, stats)",
+                    "enum": ["query", "explain", "schema", "list_databases", "stats"]
                 },
                 "database": {
                     "type": "string",
@@ -75,7 +84,16 @@ func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
                 },
                 "table": {
                     "type": "string",
-                    "description": "Table name (for schema operation)"
+                    "description": "Table name, optionally qualified as schema.table (for schema operation)"
+                },
+                "analyze": {
+                    "type": "boolean",
+                    "description": "For explain: execute the query to collect actual run times (EXPLAIN ANALYZE). Default false"
+                },
+                "format": {
+                    "type": "string",
+                    "description": "For explain: output format of the plan (default: text)",
+                    "enum": ["text", "json"]
                 }
             },
             "required": ["operation"]
@@ -98,6 +116,8 @@ func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
 				Database  string `json:"database"`
 				Query     string `json:"query"`
 				Table     string `json:"table"`
+				Analyze   bool   `json:"analyze"`
+				Format    string `json:"format"`
 			}
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -106,7 +126,7 @@ func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
 					"raw_input":                 string(params.Arguments),
 				}).Error("Failed to unmarshal input parameters")
 				span.RecordError(err)
-				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
 			}
 
 			// Handle list_databases operation first as it doesn't need a connection
@@ -116,43 +136,54 @@ func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
 
 			// For all other operations, we need a database
 			if input.Database == "" {
-				return returnErrorOutput(fmt.Errorf("database identifier is required for operation: %s", input.Operation)), nil
+				return returnErrorOutput(validationErrorf("database identifier is required for operation: %s", input.Operation)), nil
+			}
+
+			if err := p.validateDatabase(input.Database); err != nil {
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
 			}
 
 			// Get database connection
 			db, err := p.getConnection(input.Database)
 			if err != nil {
 				span.RecordError(err)
-				return returnErrorOutput(fmt.Errorf("failed to get database connection: %w", err)), nil
+				return returnErrorOutput(newToolError(ErrorKindUpstream, fmt.Errorf("failed to get database connection: %w", err))), nil
 			}
 
 			switch input.Operation {
 			case "query":
 				if input.Query == "" {
-					return goai.CallToolResult{}, fmt.Errorf("query is required for operation 'query'")
+					return returnErrorOutput(validationErrorf("query is required for operation 'query'")), nil
 				}
 				return p.executeQuery(ctx, db, input.Query)
 
 			case "explain":
 				if input.Query == "" {
-					return goai.CallToolResult{}, fmt.Errorf("query is required for operation 'explain'")
+					return returnErrorOutput(validationErrorf("query is required for operation 'explain'")), nil
+				}
+				if input.Format != "" && input.Format != "text" && input.Format != "json" {
+					return returnErrorOutput(validationErrorf("invalid format: %s (allowed: text, json)", input.Format)), nil
 				}
-				return p.executeExplain(ctx, db, input.Query)
+				return p.executeExplain(ctx, db, input.Query, input.Analyze, input.Format)
 
 			case "schema":
 				if input.Table == "" {
-					return returnErrorOutput(fmt.Errorf("table is required for operation 'schema'")), nil
+					return returnErrorOutput(validationErrorf("table is required for operation 'schema'")), nil
 				}
 				return p.getTableSchema(ctx, db, input.Table)
 
+			case "stats":
+				return p.getPoolStats(input.Database, db)
+
 			default:
 				p.logger.WithFields(map[string]interface{}{
 					"operation": input.Operation,
 				}).Error("Invalid operation")
-				return goai.CallToolResult{}, fmt.Errorf("unknown operation: %s", input.Operation)
+				return returnErrorOutput(validationErrorf("unknown operation: %s", input.Operation)), nil
 			}
 		},
-	}
+	})
 }
 
 func (p *PostgreSQL) executeQuery(ctx context.Context, db *sql.DB, query string) (goai.CallToolResult, error) {
@@ -162,6 +193,10 @@ func (p *PostgreSQL) executeQuery(ctx context.Context, db *sql.DB, query string)
 		"query":     query,
 	}).Info("Executing query")
 
+	if !returnsRows(query) {
+		return p.executeStatement(ctx, db, query)
+	}
+
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return goai.CallToolResult{}, err
@@ -172,6 +207,20 @@ func (p *PostgreSQL) executeQuery(ctx context.Context, db *sql.DB, query string)
 	if err != nil {
 		return returnErrorOutput(err), nil
 	}
+	if len(columns) == 0 {
+		// A statement without a result set, e.g. CREATE TABLE
+		for rows.Next() {
+		}
+		if err := rows.Err(); err != nil {
+			return returnErrorOutput(err), nil
+		}
+		return goai.CallToolResult{
+			Content: []goai.ToolResultContent{{
+				Type: "text",
+				Text: noRowsReturnedMessage,
+			}},
+		}, nil
+	}
 
 	var result strings.Builder
 	result.WriteString(strings.Join(columns, " | ") + "\n")
@@ -194,6 +243,9 @@ func (p *PostgreSQL) executeQuery(ctx context.Context, db *sql.DB, query string)
 		}
 		result.WriteString(strings.Join(rowValues, " | ") + "\n")
 	}
+	if err := rows.Err(); err != nil {
+		return returnErrorOutput(err), nil
+	}
 
 	p.logger.WithFields(map[string]interface{}{
 		"tool":      PostgreSQLToolName,
@@ -209,14 +261,104 @@ func (p *PostgreSQL) executeQuery(ctx context.Context, db *sql.DB, query string)
 	}, nil
 }
 
-func (p *PostgreSQL) executeExplain(ctx context.Context, db *sql.DB, query string) (goai.CallToolResult, error) {
+// executeStatement runs a statement that does not return rows (e.g. UPDATE, INSERT)
+// and reports the number of affected rows
+func (p *PostgreSQL) executeStatement(ctx context.Context, db *sql.DB, query string) (goai.CallToolResult, error) {
+	res, err := db.ExecContext(ctx, query)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"tool":          PostgreSQLToolName,
+		"operation":     "executeStatement",
+		"query":         query,
+		"rows_affected": affected,
+	}).Info("Statement executed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "text",
+			Text: fmt.Sprintf("%d rows affected", affected),
+		}},
+	}, nil
+}
+
+// returnsRows reports whether the query should run as a query rather than a
+// statement. Only INSERT, UPDATE, DELETE and MERGE without a RETURNING clause
+// run as statements, so their affected rows can be reported; anything else,
+// including unknown keywords, runs as a query so no result set is discarded.
+func returnsRows(query string) bool {
+	fields := strings.Fields(stripLeadingSQLComments(query))
+	if len(fields) == 0 {
+		return true
+	}
+
+	switch strings.ToUpper(fields[0]) {
+	case "INSERT", "UPDATE", "DELETE", "MERGE":
+		for _, f := range fields[1:] {
+			if strings.EqualFold(f, "RETURNING") {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// stripLeadingSQLComments removes the whitespace, opening parentheses, line
+// comments and (nested) block comments preceding the first keyword of query
+func stripLeadingSQLComments(query string) string {
+	for {
+		query = strings.TrimLeft(query, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(query, "--"):
+			end := strings.IndexByte(query, '\n')
+			if end < 0 {
+				return ""
+			}
+			query = query[end+1:]
+		case strings.HasPrefix(query, "/*"):
+			depth, i := 0, 0
+			for i < len(query) {
+				if strings.HasPrefix(query[i:], "/*") {
+					depth++
+					i += 2
+				} else if strings.HasPrefix(query[i:], "*/") {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+			if depth > 0 {
+				return ""
+			}
+			query = query[i:]
+		default:
+			return query
+		}
+	}
+}
+
+func (p *PostgreSQL) executeExplain(ctx context.Context, db *sql.DB, query string, analyze bool, format string) (goai.CallToolResult, error) {
 	p.logger.WithFields(map[string]interface{}{
 		"tool":      PostgreSQLToolName,
 		"operation": "executeExplain",
 		"query":     query,
+		"analyze":   analyze,
 	}).Info("Executing explain")
 
-	rows, err := db.QueryContext(ctx, "EXPLAIN ANALYZE "+query)
+	rows, err := db.QueryContext(ctx, buildExplainQuery(query, analyze, format))
 	if err != nil {
 		p.logger.WithFields(map[string]interface{}{
 			goai.ErrorLogField: err,
@@ -250,6 +392,23 @@ func (p *PostgreSQL) executeExplain(ctx context.Context, db *sql.DB, query strin
 	}, nil
 }
 
+// buildExplainQuery prefixes the query with EXPLAIN and its options. ANALYZE
+// executes the statement, so it is only added when explicitly requested.
+func buildExplainQuery(query string, analyze bool, format string) string {
+	var options []string
+	if analyze {
+		options = append(options, "ANALYZE")
+	}
+	if format == "json" {
+		options = append(options, "FORMAT JSON")
+	}
+
+	if len(options) == 0 {
+		return "EXPLAIN " + query
+	}
+	return fmt.Sprintf("EXPLAIN (%s) %s", strings.Join(options, ", "), query)
+}
+
 func (p *PostgreSQL) getTableSchema(ctx context.Context, db *sql.DB, tableName string) (goai.CallToolResult, error) {
 	p.logger.WithFields(map[string]interface{}{
 		"tool":      PostgreSQLToolName,
@@ -262,10 +421,12 @@ func (p *PostgreSQL) getTableSchema(ctx context.Context, db *sql.DB, tableName s
                is_nullable, column_default
         FROM information_schema.columns 
         WHERE table_name = $1
+          AND table_schema = COALESCE(NULLIF($2, ''), current_schema())
         ORDER BY ordinal_position;
     `
 
-	rows, err := db.QueryContext(ctx, query, tableName)
+	schemaName, table := splitTableName(tableName)
+	rows, err := db.QueryContext(ctx, query, table, schemaName)
 	if err != nil {
 		return returnErrorOutput(err), nil
 	}
@@ -294,6 +455,14 @@ func (p *PostgreSQL) getTableSchema(ctx context.Context, db *sql.DB, tableName s
 			defaultValue.String))
 	}
 
+	if err := p.writeTableIndexes(ctx, db, schemaName, table, &schema); err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	if err := p.writeTableConstraints(ctx, db, schemaName, table, &schema); err != nil {
+		return returnErrorOutput(err), nil
+	}
+
 	p.logger.WithFields(map[string]interface{}{
 		"tool":      PostgreSQLToolName,
 		"operation": "getTableSchema",
@@ -308,6 +477,153 @@ func (p *PostgreSQL) getTableSchema(ctx context.Context, db *sql.DB, tableName s
 	}, nil
 }
 
+// splitTableName splits a schema-qualified table name into its schema and
+// table. The schema is empty when the name is not qualified, which the schema
+// queries read as the current schema.
+func splitTableName(name string) (string, string) {
+	if schemaName, table, ok := strings.Cut(name, "."); ok {
+		return schemaName, table
+	}
+	return "", name
+}
+
+// writeTableIndexes appends the indexes of the table to the schema description
+func (p *PostgreSQL) writeTableIndexes(ctx context.Context, db *sql.DB, schemaName, tableName string, schema *strings.Builder) error {
+	query := `
+        SELECT indexname, indexdef
+        FROM pg_indexes
+        WHERE tablename = $1
+          AND schemaname = COALESCE(NULLIF($2, ''), current_schema())
+        ORDER BY indexname;
+    `
+
+	rows, err := db.QueryContext(ctx, query, tableName, schemaName)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve indexes: %w", err)
+	}
+	defer rows.Close()
+
+	schema.WriteString("\nIndexes:\n")
+	schema.WriteString("Index Name | Definition\n")
+	schema.WriteString("-----------|-----------\n")
+
+	for rows.Next() {
+		var indexName, indexDef string
+		if err := rows.Scan(&indexName, &indexDef); err != nil {
+			return err
+		}
+		schema.WriteString(fmt.Sprintf("%s | %s\n", indexName, indexDef))
+	}
+
+	return rows.Err()
+}
+
+// writeTableConstraints appends the primary key, unique, check and foreign key
+// constraints of the table to the schema description. Each column of a
+// multi-column foreign key is paired with the referenced column at the same
+// position.
+func (p *PostgreSQL) writeTableConstraints(ctx context.Context, db *sql.DB, schemaName, tableName string, schema *strings.Builder) error {
+	query := `
+        SELECT tc.constraint_name, tc.constraint_type, kcu.column_name,
+               rcu.table_name, rcu.column_name
+        FROM information_schema.table_constraints tc
+        LEFT JOIN information_schema.key_column_usage kcu
+            ON tc.constraint_name = kcu.constraint_name
+            AND tc.table_schema = kcu.table_schema
+            AND tc.table_name = kcu.table_name
+        LEFT JOIN information_schema.referential_constraints rc
+            ON tc.constraint_type = 'FOREIGN KEY'
+            AND tc.constraint_name = rc.constraint_name
+            AND tc.constraint_schema = rc.constraint_schema
+        LEFT JOIN information_schema.key_column_usage rcu
+            ON rc.unique_constraint_name = rcu.constraint_name
+            AND rc.unique_constraint_schema = rcu.constraint_schema
+            AND kcu.position_in_unique_constraint = rcu.ordinal_position
+        WHERE tc.table_name = $1
+          AND tc.table_schema = COALESCE(NULLIF($2, ''), current_schema())
+        ORDER BY tc.constraint_name, kcu.ordinal_position;
+    `
+
+	rows, err := db.QueryContext(ctx, query, tableName, schemaName)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve constraints: %w", err)
+	}
+	defer rows.Close()
+
+	schema.WriteString("\nConstraints:\n")
+	schema.WriteString("Constraint Name | Type | Column | References\n")
+	schema.WriteString("----------------|------|--------|-----------\n")
+
+	for rows.Next() {
+		var (
+			constraintName, constraintType          string
+			columnName, foreignTable, foreignColumn sql.NullString
+		)
+		if err := rows.Scan(&constraintName, &constraintType, &columnName, &foreignTable, &foreignColumn); err != nil {
+			return err
+		}
+
+		references := ""
+		if foreignTable.Valid {
+			references = fmt.Sprintf("%s(%s)", foreignTable.String, foreignColumn.String)
+		}
+		schema.WriteString(fmt.Sprintf("%s | %s | %s | %s\n", constraintName, constraintType, columnName.String, references))
+	}
+
+	return rows.Err()
+}
+
+// postgresqlPoolStats is the JSON representation of the connection pool statistics of a database
+type postgresqlPoolStats struct {
+	Database           string `json:"database"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+// getPoolStats reports the connection pool statistics of the database, which
+// helps diagnosing pool exhaustion
+func (p *PostgreSQL) getPoolStats(dbName string, db *sql.DB) (goai.CallToolResult, error) {
+	stats := db.Stats()
+	output, err := json.MarshalIndent(postgresqlPoolStats{
+		Database:           dbName,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}, "", "  ")
+	if err != nil {
+		return goai.CallToolResult{}, newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal pool stats: %w", err))
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"tool":             PostgreSQLToolName,
+		"operation":        "getPoolStats",
+		"database":         dbName,
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"wait_count":       stats.WaitCount,
+	}).Info("Pool statistics retrieved successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "text",
+			Text: string(output),
+		}},
+	}, nil
+}
+
 // New helper method to list available databases
 func (p *PostgreSQL) listAvailableDatabases() goai.CallToolResult {
 	p.logger.WithFields(map[string]interface{}{
@@ -337,6 +653,52 @@ func (p *PostgreSQL) listAvailableDatabases() goai.CallToolResult {
 	}
 }
 
+// validateDatabase rejects malformed database identifiers and identifiers that
+// are neither connected nor configured through environment variables
+func (p *PostgreSQL) validateDatabase(dbName string) error {
+	if !databaseIdentifierPattern.MatchString(dbName) {
+		return validationErrorf("invalid database identifier %q: only letters, digits and underscores are allowed", dbName)
+	}
+
+	available := p.availableDatabases()
+	for _, name := range available {
+		if name == dbName {
+			return nil
+		}
+	}
+
+	if len(available) == 0 {
+		return newToolError(ErrorKindNotFound, fmt.Errorf("unknown database %q: no databases are configured", dbName))
+	}
+	return newToolError(ErrorKindNotFound, fmt.Errorf("unknown database %q (available: %s)", dbName, strings.Join(available, ", ")))
+}
+
+// availableDatabases returns the sorted identifiers of connected databases and
+// of databases configured through <NAME>_DB_HOST environment variables
+func (p *PostgreSQL) availableDatabases() []string {
+	names := make(map[string]bool)
+
+	p.mu.RLock()
+	for dbName := range p.connPool {
+		names[dbName] = true
+	}
+	p.mu.RUnlock()
+
+	for _, env := range os.Environ() {
+		key, _, found := strings.Cut(env, "=")
+		if found && strings.HasSuffix(key, "_DB_HOST") {
+			names[strings.ToLower(strings.TrimSuffix(key, "_DB_HOST"))] = true
+		}
+	}
+
+	databases := make([]string, 0, len(names))
+	for name := range names {
+		databases = append(databases, name)
+	}
+	sort.Strings(databases)
+	return databases
+}
+
 func (p *PostgreSQL) initializeConnections() error {
 	var lastError error
 	// Get all environment variables
@@ -386,25 +748,20 @@ func (p *PostgreSQL) initializeConnections() error {
 }
 
 func (p *PostgreSQL) initializeConnection(dbName string, config DBConnection) error {
-	connStr := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		config.Host,
-		config.Port,
-		config.User,
-		config.Password,
-		config.DBName,
-		config.SSLMode,
-	)
+	p.logger.WithFields(map[string]interface{}{
+		"database":   dbName,
+		"connection": config.connectionString(true),
+	}).Info("Connecting to database")
 
-	db, err := sql.Open("postgres", connStr)
+	db, err := sql.Open("postgres", config.connectionString(false))
 	if err != nil {
-		return fmt.Errorf("failed to open database connection: %w", err)
+		return fmt.Errorf("failed to open database connection: %w", redactPassword(err, config.Password))
 	}
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
 		db.Close()
-		return fmt.Errorf("failed to ping database: %w", err)
+		return fmt.Errorf("failed to ping database: %w", redactPassword(err, config.Password))
 	}
 
 	// Configure connection pool
@@ -419,6 +776,76 @@ func (p *PostgreSQL) initializeConnection(dbName string, config DBConnection) er
 	return nil
 }
 
+// connectionString builds the driver connection string. With redact set the
+// password is masked so the result is safe to log.
+func (c DBConnection) connectionString(redact bool) string {
+	password := c.Password
+	if redact && password != "" {
+		password = redactedPassword
+	}
+
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		c.Host,
+		c.Port,
+		c.User,
+		password,
+		c.DBName,
+		c.SSLMode,
+	)
+}
+
+const redactedPassword = "****"
+
+// passwordRedactedError hides a password that a driver echoed back in its error message
+type passwordRedactedError struct {
+	err     error
+	message string
+}
+
+func (e *passwordRedactedError) Error() string { return e.message }
+
+func (e *passwordRedactedError) Unwrap() error { return e.err }
+
+// redactPassword masks the password component of a connection string echoed
+// in the error message. Only the password= value is replaced, so a short
+// password does not mangle the rest of the message.
+func redactPassword(err error, password string) error {
+	component := "password=" + password
+	if password == "" || !strings.Contains(err.Error(), component) {
+		return err
+	}
+	return &passwordRedactedError{
+		err:     err,
+		message: strings.ReplaceAll(err.Error(), component, "password="+redactedPassword),
+	}
+}
+
+// Close closes all pooled database connections and clears the pool.
+// It returns the first error encountered while closing connections.
+func (p *PostgreSQL) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var firstErr error
+	for dbName, db := range p.connPool {
+		if err := db.Close(); err != nil {
+			p.logger.WithFields(map[string]interface{}{
+				goai.ErrorLogField: err,
+				"database":         dbName,
+			}).Error("Failed to close database connection")
+
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to close connection for database %s: %w", dbName, err)
+			}
+		}
+	}
+
+	p.connPool = make(map[string]*sql.DB)
+
+	return firstErr
+}
+
 // getConnection returns a connection to the specified database
 func (p *PostgreSQL) getConnection(dbName string) (*sql.DB, error) {
 	p.mu.RLock()
@@ -2,7 +2,12 @@ package mcptools
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/shaharia-lab/goai"
@@ -100,6 +105,12 @@ func TestPostgreSQL_InvalidOperation(t *testing.T) {
 	})).Return(logger)
 	logger.On("Error", "Invalid operation").Return()
 
+	// The operation is rejected by input validation before the handler runs
+	logger.On("WithFields", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		return fields["tool_name"] == PostgreSQLToolName && fields[goai.ErrorLogField] != nil
+	})).Return(logger)
+	logger.On("Error", []interface{}{"Input validation failed"}).Return()
+
 	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
 
 	input := map[string]interface{}{
@@ -170,3 +181,557 @@ func TestPostgreSQL_ListDatabases(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestPostgreSQL_Close(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	logger := new(MockLogger)
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	sqlMock.ExpectClose()
+
+	err = pg.Close()
+
+	assert.NoError(t, err)
+	assert.Empty(t, pg.connPool)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPostgreSQL_QueryUpdateReportsAffectedRows(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	sqlMock.ExpectExec("UPDATE test_table").WillReturnResult(sqlmock.NewResult(0, 3))
+
+	input := map[string]interface{}{
+		"operation": "query",
+		"database":  "test_db",
+		"query":     "UPDATE test_table SET name = 'updated' WHERE id > 1",
+	}
+	inputJSON, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := pg.PostgreSQLAllInOneTool().Handler(
+		context.Background(),
+		goai.CallToolParams{
+			Name:      PostgreSQLToolName,
+			Arguments: inputJSON,
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "3 rows affected", result.Content[0].Text)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPostgreSQL_Stats(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(10)
+
+	// Hold a connection so the pool reports it as in use
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"operation": "stats",
+		"database":  "test_db",
+	})
+	require.NoError(t, err)
+
+	result, err := pg.PostgreSQLAllInOneTool().Handler(
+		context.Background(),
+		goai.CallToolParams{
+			Name:      PostgreSQLToolName,
+			Arguments: inputJSON,
+		},
+	)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &fields))
+	for _, field := range []string{
+		"database", "max_open_connections", "open_connections", "in_use", "idle",
+		"wait_count", "wait_duration", "max_idle_closed", "max_idle_time_closed", "max_lifetime_closed",
+	} {
+		assert.Contains(t, fields, field)
+	}
+
+	var stats postgresqlPoolStats
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &stats))
+	assert.Equal(t, "test_db", stats.Database)
+	assert.Equal(t, 10, stats.MaxOpenConnections)
+	assert.Equal(t, 1, stats.InUse)
+	assert.Equal(t, stats.InUse+stats.Idle, stats.OpenConnections)
+	assert.Equal(t, int64(0), stats.WaitCount)
+	assert.Equal(t, "0s", stats.WaitDuration)
+}
+
+func TestPostgreSQL_QueryWithLeadingComment(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	sqlMock.ExpectQuery("SELECT id FROM test_table").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	sqlMock.ExpectQuery("CREATE TABLE other").WillReturnRows(sqlmock.NewRows(nil))
+
+	for _, tt := range []struct {
+		query    string
+		expected string
+	}{
+		{query: "-- newest first\nSELECT id FROM test_table", expected: "id\n--\n7\n"},
+		{query: "CREATE TABLE other (id int)", expected: noRowsReturnedMessage},
+	} {
+		inputJSON, err := json.Marshal(map[string]interface{}{
+			"operation": "query",
+			"database":  "test_db",
+			"query":     tt.query,
+		})
+		require.NoError(t, err)
+
+		result, err := pg.PostgreSQLAllInOneTool().Handler(
+			context.Background(),
+			goai.CallToolParams{
+				Name:      PostgreSQLToolName,
+				Arguments: inputJSON,
+			},
+		)
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content[0].Text)
+		assert.Equal(t, tt.expected, result.Content[0].Text)
+	}
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestReturnsRows(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"SELECT * FROM users", true},
+		{"  with cte AS (SELECT 1) SELECT * FROM cte", true},
+		{"(SELECT 1)", true},
+		{"UPDATE users SET name = 'x'", false},
+		{"INSERT INTO users (name) VALUES ('x')", false},
+		{"INSERT INTO users (name) VALUES ('x') RETURNING id", true},
+		{"DELETE FROM users", false},
+		{"CREATE TABLE t (id int)", true},
+		{"PRAGMA table_info(t)", true},
+		{"-- latest users\nSELECT * FROM users", true},
+		{"/* outer /* nested */ comment */ SELECT 1", true},
+		{"/* bulk fix */ UPDATE users SET name = 'x'", false},
+		{"-- cleanup\n  DELETE FROM users", false},
+		{"MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN DELETE", false},
+		{"-- only a comment", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, returnsRows(tt.query))
+		})
+	}
+}
+
+func TestPostgreSQL_Explain(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         map[string]interface{}
+		expectedQuery string
+		planLine      string
+	}{
+		{
+			name:          "plain explain does not analyze",
+			input:         map[string]interface{}{},
+			expectedQuery: "EXPLAIN DELETE FROM test_table",
+			planLine:      "Delete on test_table",
+		},
+		{
+			name:          "explain analyze",
+			input:         map[string]interface{}{"analyze": true},
+			expectedQuery: "EXPLAIN (ANALYZE) DELETE FROM test_table",
+			planLine:      "Delete on test_table (actual time=0.010..0.010 rows=0 loops=1)",
+		},
+		{
+			name:          "json format",
+			input:         map[string]interface{}{"format": "json"},
+			expectedQuery: "EXPLAIN (FORMAT JSON) DELETE FROM test_table",
+			planLine:      `[{"Plan": {"Node Type": "ModifyTable"}}]`,
+		},
+		{
+			name:          "json format with analyze",
+			input:         map[string]interface{}{"format": "json", "analyze": true},
+			expectedQuery: "EXPLAIN (ANALYZE, FORMAT JSON) DELETE FROM test_table",
+			planLine:      `[{"Plan": {"Node Type": "ModifyTable"}, "Execution Time": 0.02}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger)
+			logger.On("Info", mock.Anything).Return()
+
+			pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+			pg.mu.Lock()
+			pg.connPool["test_db"] = db
+			pg.mu.Unlock()
+
+			sqlMock.ExpectQuery(tt.expectedQuery).WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(tt.planLine))
+
+			input := map[string]interface{}{
+				"operation": "explain",
+				"database":  "test_db",
+				"query":     "DELETE FROM test_table",
+			}
+			for k, v := range tt.input {
+				input[k] = v
+			}
+			inputJSON, err := json.Marshal(input)
+			require.NoError(t, err)
+
+			result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      PostgreSQLToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+			assert.Equal(t, tt.planLine+"\n", result.Content[0].Text)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgreSQL_ExplainInvalidFormat(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+	logger.On("Error", []interface{}{"Input validation failed"}).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"operation": "explain",
+		"database":  "test_db",
+		"query":     "SELECT 1",
+		"format":    "yaml",
+	})
+	require.NoError(t, err)
+
+	result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      PostgreSQLToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, `format: format must be one of the following: "text", "json"`)
+}
+
+func TestPostgreSQL_ExplainRequiresQuery(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"operation": "explain",
+		"database":  "test_db",
+	})
+	require.NoError(t, err)
+
+	result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      PostgreSQLToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "query is required for operation 'explain'", result.Content[0].Text)
+	kind, ok := ResultErrorKind(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrorKindValidation, kind)
+}
+
+func TestPostgreSQL_SchemaIncludesIndexesAndConstraints(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	sqlMock.ExpectQuery("FROM information_schema.columns").WithArgs("orders", "").WillReturnRows(
+		sqlmock.NewRows([]string{"column_name", "data_type", "character_maximum_length", "is_nullable", "column_default"}).
+			AddRow("id", "integer", nil, "NO", "nextval('orders_id_seq'::regclass)").
+			AddRow("user_id", "integer", nil, "YES", nil))
+	sqlMock.ExpectQuery("FROM pg_indexes").WithArgs("orders", "").WillReturnRows(
+		sqlmock.NewRows([]string{"indexname", "indexdef"}).
+			AddRow("orders_pkey", "CREATE UNIQUE INDEX orders_pkey ON public.orders USING btree (id)").
+			AddRow("orders_user_id_idx", "CREATE INDEX orders_user_id_idx ON public.orders USING btree (user_id)"))
+	sqlMock.ExpectQuery("FROM information_schema.table_constraints").WithArgs("orders", "").WillReturnRows(
+		sqlmock.NewRows([]string{"constraint_name", "constraint_type", "column_name", "table_name", "column_name"}).
+			AddRow("orders_pkey", "PRIMARY KEY", "id", nil, nil).
+			AddRow("orders_user_id_fkey", "FOREIGN KEY", "user_id", "users", "id"))
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"operation": "schema",
+		"database":  "test_db",
+		"table":     "orders",
+	})
+	require.NoError(t, err)
+
+	result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      PostgreSQLToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+	text := result.Content[0].Text
+	assert.Contains(t, text, "Table: orders")
+	assert.Contains(t, text, "user_id | integer | 0 | YES | ")
+	assert.Contains(t, text, "Indexes:\n")
+	assert.Contains(t, text, "orders_user_id_idx | CREATE INDEX orders_user_id_idx ON public.orders USING btree (user_id)\n")
+	assert.Contains(t, text, "Constraints:\n")
+	assert.Contains(t, text, "orders_pkey | PRIMARY KEY | id | \n")
+	assert.Contains(t, text, "orders_user_id_fkey | FOREIGN KEY | user_id | users(id)\n")
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPostgreSQL_SchemaPairsMultiColumnForeignKeys(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+	pg.mu.Lock()
+	pg.connPool["test_db"] = db
+	pg.mu.Unlock()
+
+	sqlMock.ExpectQuery("(?s)FROM information_schema.columns.*table_schema = ").WithArgs("order_items", "sales").WillReturnRows(
+		sqlmock.NewRows([]string{"column_name", "data_type", "character_maximum_length", "is_nullable", "column_default"}).
+			AddRow("order_id", "integer", nil, "NO", nil).
+			AddRow("order_version", "integer", nil, "NO", nil))
+	sqlMock.ExpectQuery("(?s)FROM pg_indexes.*schemaname = ").WithArgs("order_items", "sales").WillReturnRows(
+		sqlmock.NewRows([]string{"indexname", "indexdef"}))
+	sqlMock.ExpectQuery("(?s)kcu.position_in_unique_constraint = rcu.ordinal_position.*tc.table_schema = ").WithArgs("order_items", "sales").WillReturnRows(
+		sqlmock.NewRows([]string{"constraint_name", "constraint_type", "column_name", "table_name", "column_name"}).
+			AddRow("order_items_order_fkey", "FOREIGN KEY", "order_id", "orders", "id").
+			AddRow("order_items_order_fkey", "FOREIGN KEY", "order_version", "orders", "version"))
+
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"operation": "schema",
+		"database":  "test_db",
+		"table":     "sales.order_items",
+	})
+	require.NoError(t, err)
+
+	result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      PostgreSQLToolName,
+		Arguments: inputJSON,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+	text := result.Content[0].Text
+	assert.Contains(t, text, "order_items_order_fkey | FOREIGN KEY | order_id | orders(id)\n")
+	assert.Contains(t, text, "order_items_order_fkey | FOREIGN KEY | order_version | orders(version)\n")
+	assert.Equal(t, 2, strings.Count(text, "order_items_order_fkey"))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSplitTableName(t *testing.T) {
+	schemaName, table := splitTableName("sales.orders")
+	assert.Equal(t, "sales", schemaName)
+	assert.Equal(t, "orders", table)
+
+	schemaName, table = splitTableName("orders")
+	assert.Empty(t, schemaName)
+	assert.Equal(t, "orders", table)
+}
+
+// dsnEchoingDriver fails every connection with an error that quotes the
+// connection string, like drivers reporting malformed DSNs do
+type dsnEchoingDriver struct{}
+
+func (dsnEchoingDriver) Open(dsn string) (driver.Conn, error) {
+	return nil, fmt.Errorf("cannot connect using %q", dsn)
+}
+
+func TestPostgreSQL_ConnectionErrorsRedactPassword(t *testing.T) {
+	registered := false
+	for _, name := range sql.Drivers() {
+		registered = registered || name == "postgres"
+	}
+	if !registered {
+		sql.Register("postgres", dsnEchoingDriver{})
+	}
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+
+	pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+	err := pg.initializeConnection("test_db", DBConnection{
+		Host:     "localhost",
+		Port:     "5432",
+		User:     "app",
+		Password: "s3cr3t-value",
+		DBName:   "test_db",
+		SSLMode:  "disable",
+	})
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t-value")
+	if !registered {
+		assert.Contains(t, err.Error(), "password=****")
+	}
+
+	for _, call := range logger.Calls {
+		if call.Method == "WithFields" {
+			assert.NotContains(t, fmt.Sprint(call.Arguments...), "s3cr3t-value")
+		}
+	}
+}
+
+func TestRedactPassword(t *testing.T) {
+	original := errors.New(`cannot connect using "host=localhost user=app password=hunter2 dbname=app"`)
+	redacted := redactPassword(original, "hunter2")
+
+	assert.Equal(t, `cannot connect using "host=localhost user=app password=**** dbname=app"`, redacted.Error())
+	assert.ErrorIs(t, redacted, original)
+	assert.Equal(t, original, redactPassword(original, ""))
+}
+
+func TestRedactPassword_ShortPasswordKeepsMessage(t *testing.T) {
+	original := errors.New(`dial tcp: lookup database: no such host in "host=database user=app password=a dbname=app"`)
+	redacted := redactPassword(original, "a")
+
+	assert.Equal(t, `dial tcp: lookup database: no such host in "host=database user=app password=**** dbname=app"`, redacted.Error())
+
+	unrelated := errors.New("database unavailable")
+	assert.Equal(t, unrelated, redactPassword(unrelated, "a"))
+}
+
+func TestPostgreSQL_ValidateDatabase(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Setenv("ANALYTICS_DB_HOST", "localhost")
+
+	tests := []struct {
+		name          string
+		database      string
+		expectedError string
+	}{
+		{
+			name:          "invalid format",
+			database:      "main; DROP TABLE users",
+			expectedError: `invalid database identifier "main; DROP TABLE users"`,
+		},
+		{
+			name:          "unknown database",
+			database:      "billing",
+			expectedError: `unknown database "billing" (available: analytics, test_db)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(MockLogger)
+			logger.On("WithFields", mock.Anything).Return(logger)
+			logger.On("Info", mock.Anything).Return()
+
+			pg := NewPostgreSQL(logger, PostgreSQLConfig{})
+			pg.connPool["test_db"] = db
+
+			inputJSON, err := json.Marshal(map[string]interface{}{
+				"operation": "query",
+				"database":  tt.database,
+				"query":     "SELECT 1",
+			})
+			require.NoError(t, err)
+
+			result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+				Name:      PostgreSQLToolName,
+				Arguments: inputJSON,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+		})
+	}
+}
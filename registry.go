@@ -0,0 +1,92 @@
+package mcptools
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// ToolRegistry holds tools by name so an MCP server can be wired up from a
+// single collection. Tools are returned in registration order.
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]goai.Tool
+	order []string
+}
+
+// NewToolRegistry creates an empty ToolRegistry
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{
+		tools: make(map[string]goai.Tool),
+	}
+}
+
+// Register adds a tool to the registry. It fails if the tool has no name or
+// a tool with the same name is already registered.
+func (r *ToolRegistry) Register(tool goai.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.validate(tool, nil); err != nil {
+		return err
+	}
+
+	r.tools[tool.Name] = tool
+	r.order = append(r.order, tool.Name)
+	return nil
+}
+
+// RegisterAll adds several tools at once, e.g. all tools of a client such as
+// GitHub.Tools(). Either every tool is registered or, on error, none is.
+func (r *ToolRegistry) RegisterAll(tools ...goai.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		if err := r.validate(tool, pending); err != nil {
+			return err
+		}
+		pending[tool.Name] = true
+	}
+
+	for _, tool := range tools {
+		r.tools[tool.Name] = tool
+		r.order = append(r.order, tool.Name)
+	}
+	return nil
+}
+
+// All returns every registered tool in registration order
+func (r *ToolRegistry) All() []goai.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tools := make([]goai.Tool, 0, len(r.order))
+	for _, name := range r.order {
+		tools = append(tools, r.tools[name])
+	}
+	return tools
+}
+
+// Get looks up a registered tool by name
+func (r *ToolRegistry) Get(name string) (goai.Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tool, ok := r.tools[name]
+	return tool, ok
+}
+
+// validate checks that the tool can be registered alongside the already
+// registered tools and the pending ones. Callers must hold the lock.
+func (r *ToolRegistry) validate(tool goai.Tool, pending map[string]bool) error {
+	if tool.Name == "" {
+		return fmt.Errorf("tool name is required")
+	}
+	if _, exists := r.tools[tool.Name]; exists || pending[tool.Name] {
+		return fmt.Errorf("tool %q is already registered", tool.Name)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package mcptools
+
+import (
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolRegistry_RegisterAndGet(t *testing.T) {
+	registry := NewToolRegistry()
+
+	require.NoError(t, registry.Register(GetWeather))
+	require.NoError(t, registry.Register(NewGrep(new(MockLogger)).GrepAllInOneTool()))
+
+	tool, ok := registry.Get(GrepToolName)
+	assert.True(t, ok)
+	assert.Equal(t, GrepToolName, tool.Name)
+
+	_, ok = registry.Get("missing")
+	assert.False(t, ok)
+
+	all := registry.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, "get_weather", all[0].Name)
+	assert.Equal(t, GrepToolName, all[1].Name)
+}
+
+func TestToolRegistry_DuplicateName(t *testing.T) {
+	registry := NewToolRegistry()
+
+	require.NoError(t, registry.Register(GetWeather))
+
+	err := registry.Register(GetWeather)
+	assert.EqualError(t, err, `tool "get_weather" is already registered`)
+
+	err = registry.Register(goai.Tool{})
+	assert.EqualError(t, err, "tool name is required")
+
+	assert.Len(t, registry.All(), 1)
+}
+
+func TestToolRegistry_RegisterAll(t *testing.T) {
+	gh, _, cleanup := setupGitHubTest(t)
+	defer cleanup()
+
+	registry := NewToolRegistry()
+	require.NoError(t, registry.RegisterAll(gh.Tools()...))
+
+	for _, name := range []string{GitHubIssuesToolName, GitHubPullRequestsToolName, GitHubRepositoryToolName, GitHubSearchToolName, GitHubLabelsToolName, GitHubGistsToolName, GitHubRateLimitToolName} {
+		_, ok := registry.Get(name)
+		assert.True(t, ok, name)
+	}
+
+	// A batch containing a duplicate is rejected as a whole
+	err := registry.RegisterAll(GetWeather, gh.GetIssuesTool())
+	assert.EqualError(t, err, `tool "github_issues" is already registered`)
+	_, ok := registry.Get("get_weather")
+	assert.False(t, ok)
+
+	err = registry.RegisterAll(GetWeather, GetWeather)
+	assert.EqualError(t, err, `tool "get_weather" is already registered`)
+	assert.Len(t, registry.All(), 7)
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
 
 	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const SedToolName = "sed"
@@ -16,19 +18,37 @@ const SedToolName = "sed"
 type Sed struct {
 	logger      goai.Logger
 	cmdExecutor CommandExecutor
+	config      SedConfig
+}
+
+// SedConfig holds the configuration for the Sed tool
+type SedConfig struct {
+	AllowedDirectory    string // Base directory files must reside in; empty means unrestricted
+	AllowInPlace        bool   // Allow -i/--in-place editing of files
+	AllowUnsafeCommands bool   // Allow the w, W, r, R and e commands that read/write files or run shell commands
+
+	// CommandExecutor runs the sed binary, e.g. a TimeoutCommandExecutor to bound
+	// its duration. Nil uses RealCommandExecutor.
+	CommandExecutor CommandExecutor
 }
 
 // NewSed creates a new instance of the Sed wrapper
 func NewSed(logger goai.Logger) *Sed {
+	return NewSedWithConfig(logger, SedConfig{})
+}
+
+// NewSedWithConfig creates a new instance of the Sed wrapper with the given configuration
+func NewSedWithConfig(logger goai.Logger, config SedConfig) *Sed {
 	return &Sed{
 		logger:      logger,
-		cmdExecutor: &RealCommandExecutor{},
+		cmdExecutor: commandExecutorOrDefault(config.CommandExecutor),
+		config:      config,
 	}
 }
 
 // SedAllInOneTool returns a goai.Tool that can execute sed commands
 func (s *Sed) SedAllInOneTool() goai.Tool {
-	return goai.Tool{
+	return withInputValidation(s.logger, goai.Tool{
 		Name:        SedToolName,
 		Description: "Stream editor for filtering and transforming text",
 		InputSchema: json.RawMessage(`{
@@ -45,42 +65,82 @@ func (s *Sed) SedAllInOneTool() goai.Tool {
                     },
                     "description": "Files to process"
                 },
+                "content": {
+                    "type": "string",
+                    "description": "Text to process instead of files; it is passed to sed on standard input"
+                },
                 "options": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
-                    "description": "Additional sed options (e.g., -i for in-place editing)"
+                    "description": "Additional sed flags (e.g., -n to suppress output; -i for in-place editing when enabled); files must be passed in files"
                 }
             },
             "required": ["expression"]
         }`),
 		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
 			var input struct {
 				Expression string   `json:"expression"`
 				Files      []string `json:"files"`
 				Options    []string `json:"options"`
+				Content    string   `json:"content"`
 			}
 
 			s.logger.WithFields(map[string]interface{}{
-				"tool":      params.Name,
-				"arguments": params.Arguments,
-			}).Info("Executing sed command")
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
-				return returnErrorOutput(fmt.Errorf("failed to unmarshal. err: %w", err)), nil
+				span.RecordError(err)
+				return returnErrorOutput(newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal. err: %w", err))), nil
+			}
+
+			err := s.validateInput(input.Expression, input.Files, input.Options)
+			if err == nil && input.Content != "" && len(input.Files) > 0 {
+				err = validationErrorf("content cannot be combined with files")
+			}
+			if err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"files":            input.Files,
+					"options":          input.Options,
+				}).Error("Sed input validation failed")
+				span.RecordError(err)
+
+				return returnErrorOutput(err), nil
 			}
 
-			args := append(input.Options, input.Expression)
+			// "--" keeps an expression or file starting with "-" from being parsed as an option
+			args := append(append([]string{}, input.Options...), "--", input.Expression)
 			if len(input.Files) > 0 {
 				args = append(args, input.Files...)
 			}
 
-			s.logger.Info("Executing sed command", "expression", input.Expression, "files", input.Files, "options", input.Options)
+			s.logger.WithFields(map[string]interface{}{
+				"tool_name":   params.Name,
+				"expression":  input.Expression,
+				"files":       input.Files,
+				"options":     input.Options,
+				"has_content": input.Content != "",
+			}).Info("Executing sed command")
 			cmd := exec.Command("sed", args...)
+			if input.Content != "" {
+				cmd.Stdin = strings.NewReader(input.Content)
+			}
 			output, err := s.cmdExecutor.ExecuteCommand(ctx, cmd)
 
 			if err != nil {
+				span.RecordError(err)
+
 				var exitError *exec.ExitError
 				if errors.As(err, &exitError) {
 					errorMsg := string(exitError.Stderr)
@@ -96,7 +156,7 @@ func (s *Sed) SedAllInOneTool() goai.Tool {
 						"stderr":                    errorMsg,
 					}).Error("Sed command execution failed")
 
-					return returnErrorOutput(fmt.Errorf("sed command failed (exit code %d): %s. Error: %w", exitError.ExitCode(), errorMsg, err)), nil
+					return returnErrorOutput(upstreamErrorf("sed command failed (exit code %d): %s. Error: %w", exitError.ExitCode(), errorMsg, err)), nil
 				}
 
 				s.logger.WithFields(map[string]interface{}{
@@ -105,7 +165,7 @@ func (s *Sed) SedAllInOneTool() goai.Tool {
 					"args":                      args,
 				}).Error("Sed command execution failed")
 
-				return returnErrorOutput(fmt.Errorf("send command execution failed. Error; %w", err)), nil
+				return returnErrorOutput(upstreamErrorf("send command execution failed. Error; %w", err)), nil
 			}
 
 			s.logger.WithFields(map[string]interface{}{
@@ -118,5 +178,271 @@ func (s *Sed) SedAllInOneTool() goai.Tool {
 				IsError: false,
 			}, nil
 		},
+	})
+}
+
+// validateInput checks every file operand against the allowed directory and
+// rejects in-place editing and unsafe commands unless they are enabled in the
+// configuration
+func (s *Sed) validateInput(expression string, files []string, options []string) error {
+	parsed, err := parseSedOptions(options)
+	if err != nil {
+		return err
+	}
+
+	// With -e or -f, sed reads the script from the options and treats the
+	// expression as the first input file
+	scripts := parsed.scripts
+	operands := files
+	if len(parsed.scripts) > 0 || len(parsed.scriptFiles) > 0 {
+		operands = append([]string{expression}, files...)
+	} else {
+		scripts = append([]string{expression}, scripts...)
+	}
+
+	for _, file := range append(operands, parsed.scriptFiles...) {
+		allowed, err := isPathWithinDirectory(file, s.config.AllowedDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path %s: %w", file, err)
+		}
+		if !allowed {
+			return permissionErrorf("path outside allowed directory: %s", file)
+		}
+	}
+
+	if !s.config.AllowInPlace {
+		for _, opt := range options {
+			if isSedInPlaceOption(opt) {
+				return permissionErrorf("in-place editing is not allowed: %s", opt)
+			}
+		}
+	}
+
+	if !s.config.AllowUnsafeCommands {
+		if len(parsed.scriptFiles) > 0 {
+			return permissionErrorf("script files are not allowed because their commands cannot be validated: %s", parsed.scriptFiles[0])
+		}
+
+		for _, script := range scripts {
+			if cmd, found := findUnsafeSedCommand(script); found {
+				return permissionErrorf("sed command %q is not allowed: it can read or write files or execute shell commands", cmd)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sedLongOptions are the long options of GNU sed, used to resolve the
+// abbreviations sed accepts
+var sedLongOptions = []string{
+	"binary", "debug", "expression", "file", "follow-symlinks", "help", "in-place", "line-length",
+	"null-data", "posix", "quiet", "regexp-extended", "sandbox", "separate", "silent", "unbuffered",
+	"version", "zero-terminated",
+}
+
+// resolveSedLongOption returns the long option name abbreviates, or name
+// itself when it is not an unambiguous abbreviation
+func resolveSedLongOption(name string) string {
+	resolved := ""
+	for _, option := range sedLongOptions {
+		if option == name {
+			return name
+		}
+		if strings.HasPrefix(option, name) {
+			if resolved != "" {
+				return name
+			}
+			resolved = option
+		}
+	}
+	if resolved == "" {
+		return name
+	}
+	return resolved
+}
+
+// sedOptions holds the scripts and script files given through sed options
+type sedOptions struct {
+	scripts     []string
+	scriptFiles []string
+}
+
+// parseSedOptions collects the -e and -f values of options and rejects
+// positional options, which sed would read as additional input files
+func parseSedOptions(options []string) (sedOptions, error) {
+	var parsed sedOptions
+	addValue := func(flag, value string) {
+		switch flag {
+		case "e", "expression":
+			parsed.scripts = append(parsed.scripts, value)
+		case "f", "file":
+			parsed.scriptFiles = append(parsed.scriptFiles, value)
+		}
+	}
+
+	for i := 0; i < len(options); i++ {
+		opt := options[i]
+		if !strings.HasPrefix(opt, "-") || opt == "-" || opt == "--" {
+			return parsed, validationErrorf("invalid option %q: options must be flags; pass files in files", opt)
+		}
+
+		if name, ok := strings.CutPrefix(opt, "--"); ok {
+			name, value, hasValue := strings.Cut(name, "=")
+			name = resolveSedLongOption(name)
+			switch name {
+			case "expression", "file", "line-length":
+				if !hasValue {
+					if i+1 >= len(options) {
+						return parsed, validationErrorf("option %s requires a value", opt)
+					}
+					i++
+					value = options[i]
+				}
+				addValue(name, value)
+			}
+			continue
+		}
+
+		for j, flag := range opt[1:] {
+			if flag != 'e' && flag != 'f' && flag != 'l' {
+				continue
+			}
+			// The rest of the option, or else the next option, is the flag's value
+			value := opt[j+2:]
+			if value == "" {
+				if i+1 >= len(options) {
+					return parsed, validationErrorf("option %s requires a value", opt)
+				}
+				i++
+				value = options[i]
+			}
+			addValue(string(flag), value)
+			break
+		}
+	}
+	return parsed, nil
+}
+
+// isSedInPlaceOption reports whether opt enables in-place editing, including
+// combined short flags such as -ni, suffixed forms such as -i.bak and
+// abbreviations such as --in
+func isSedInPlaceOption(opt string) bool {
+	if name, ok := strings.CutPrefix(opt, "--"); ok {
+		name, _, _ = strings.Cut(name, "=")
+		return name != "" && resolveSedLongOption(name) == "in-place"
+	}
+	if !strings.HasPrefix(opt, "-") {
+		return false
+	}
+
+	for _, flag := range opt[1:] {
+		switch flag {
+		case 'i':
+			return true
+		case 'e', 'f', 'l':
+			// The rest of the option is the flag's argument
+			return false
+		}
+	}
+	return false
+}
+
+// findUnsafeSedCommand parses a sed script and returns the first w, W, r, R or e
+// command found, including the w and e flags of the s command
+func findUnsafeSedCommand(script string) (string, bool) {
+	n := len(script)
+	i := 0
+	for i < n {
+		switch script[i] {
+		case ' ', '\t', '\n', ';', '{', '}':
+			i++
+			continue
+		}
+
+		i = skipSedAddress(script, i)
+		for i < n && (script[i] == ' ' || script[i] == '!') {
+			i++
+		}
+		if i >= n {
+			break
+		}
+
+		cmd := script[i]
+		i++
+		switch cmd {
+		case 'w', 'W', 'r', 'R', 'e':
+			return string(cmd), true
+		case 's':
+			if i >= n {
+				return "", false
+			}
+			delim := script[i]
+			i = skipSedDelimited(script, i+1, delim)
+			i = skipSedDelimited(script, i, delim)
+			for ; i < n && script[i] != ';' && script[i] != '\n' && script[i] != '}'; i++ {
+				if script[i] == 'w' || script[i] == 'e' {
+					return "s///" + string(script[i]), true
+				}
+			}
+		case 'y':
+			if i >= n {
+				return "", false
+			}
+			delim := script[i]
+			i = skipSedDelimited(script, i+1, delim)
+			i = skipSedDelimited(script, i, delim)
+		case 'a', 'i', 'c':
+			// Text arguments run until the end of the line
+			for i < n && script[i] != '\n' {
+				i++
+			}
+		default:
+			for i < n && script[i] != ';' && script[i] != '\n' && script[i] != '}' {
+				i++
+			}
+		}
+	}
+	return "", false
+}
+
+// skipSedAddress returns the index just past the address (or address range) starting at i
+func skipSedAddress(script string, i int) int {
+	n := len(script)
+	for i < n {
+		switch c := script[i]; {
+		case c >= '0' && c <= '9', c == '$', c == '~', c == '+':
+			i++
+			continue
+		case c == '/':
+			i = skipSedDelimited(script, i+1, '/')
+		case c == '\\' && i+1 < n:
+			i = skipSedDelimited(script, i+2, script[i+1])
+		case c == ',':
+			i++
+			continue
+		default:
+			return i
+		}
+		// Regex address modifiers
+		for i < n && (script[i] == 'I' || script[i] == 'M') {
+			i++
+		}
+	}
+	return i
+}
+
+// skipSedDelimited returns the index just past the next unescaped delim at or after i
+func skipSedDelimited(script string, i int, delim byte) int {
+	for i < len(script) {
+		switch script[i] {
+		case '\\':
+			i += 2
+		case delim:
+			return i + 1
+		default:
+			i++
+		}
 	}
+	return len(script)
 }
@@ -0,0 +1,251 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runSedTool(t *testing.T, sed *Sed, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+
+	inputJSON, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := sed.SedAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      SedToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func newSedTestLogger() *MockLogger {
+	mockLogger := new(MockLogger)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+	return mockLogger
+}
+
+func TestSed_Validation(t *testing.T) {
+	allowedDir := t.TempDir()
+
+	tests := []struct {
+		name          string
+		config        SedConfig
+		input         map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "file outside allowed directory",
+			config:        SedConfig{AllowedDirectory: allowedDir},
+			input:         map[string]interface{}{"expression": "s/a/b/", "files": []string{"/etc/passwd"}},
+			expectedError: "path outside allowed directory: /etc/passwd",
+		},
+		{
+			name:          "in-place flag disabled",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "s/a/b/", "files": []string{"file.txt"}, "options": []string{"-i"}},
+			expectedError: "in-place editing is not allowed: -i",
+		},
+		{
+			name:          "combined in-place flag disabled",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "s/a/b/", "options": []string{"-ni.bak"}},
+			expectedError: "in-place editing is not allowed: -ni.bak",
+		},
+		{
+			name:          "long in-place flag disabled",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "s/a/b/", "options": []string{"--in-place"}},
+			expectedError: "in-place editing is not allowed: --in-place",
+		},
+		{
+			name:          "execute command",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "1e id"},
+			expectedError: `sed command "e" is not allowed`,
+		},
+		{
+			name:          "write command after substitution",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "s/a/b/; w /etc/passwd"},
+			expectedError: `sed command "w" is not allowed`,
+		},
+		{
+			name:          "write command in extra expression",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "p", "options": []string{"-e", "/x/r /etc/shadow"}},
+			expectedError: `sed command "r" is not allowed`,
+		},
+		{
+			name:          "script file",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "p", "options": []string{"-f", "script.sed"}},
+			expectedError: "script files are not allowed",
+		},
+		{
+			name:          "positional option",
+			config:        SedConfig{AllowedDirectory: allowedDir},
+			input:         map[string]interface{}{"expression": "p", "options": []string{"/etc/passwd"}},
+			expectedError: `invalid option "/etc/passwd": options must be flags`,
+		},
+		{
+			name:          "expression read as a file with -e",
+			config:        SedConfig{AllowedDirectory: allowedDir},
+			input:         map[string]interface{}{"expression": "/etc/passwd", "options": []string{"-e", "p"}},
+			expectedError: "path outside allowed directory: /etc/passwd",
+		},
+		{
+			name:          "expression read as a file with abbreviated --expression",
+			config:        SedConfig{AllowedDirectory: allowedDir},
+			input:         map[string]interface{}{"expression": "/etc/passwd", "options": []string{"--expr=p"}},
+			expectedError: "path outside allowed directory: /etc/passwd",
+		},
+		{
+			name:          "script file outside allowed directory",
+			config:        SedConfig{AllowedDirectory: allowedDir, AllowUnsafeCommands: true},
+			input:         map[string]interface{}{"expression": filepath.Join(allowedDir, "in.txt"), "options": []string{"-nf/etc/script.sed"}},
+			expectedError: "path outside allowed directory: /etc/script.sed",
+		},
+		{
+			name:          "abbreviated in-place flag disabled",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "s/a/b/", "options": []string{"--in-pl"}},
+			expectedError: "in-place editing is not allowed: --in-pl",
+		},
+		{
+			name:          "content combined with files",
+			config:        SedConfig{},
+			input:         map[string]interface{}{"expression": "s/a/b/", "files": []string{"file.txt"}, "content": "abc"},
+			expectedError: "content cannot be combined with files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			sed := NewSedWithConfig(newSedTestLogger(), tt.config)
+			sed.cmdExecutor = mockExecutor
+
+			result := runSedTool(t, sed, tt.input)
+
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSed_InPlaceAllowed(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.Anything).Return([]byte(""), nil)
+
+	sed := NewSedWithConfig(newSedTestLogger(), SedConfig{AllowInPlace: true})
+	sed.cmdExecutor = mockExecutor
+
+	result := runSedTool(t, sed, map[string]interface{}{
+		"expression": "s/a/b/",
+		"files":      []string{"file.txt"},
+		"options":    []string{"-i"},
+	})
+
+	assert.False(t, result.IsError)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestSed_ExpressionAfterEndOfOptions(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return assert.ObjectsAreEqual([]string{"sed", "-n", "--", "s/a/b/p", "file.txt"}, cmd.Args)
+	})).Return([]byte("b\n"), nil)
+
+	sed := NewSed(newSedTestLogger())
+	sed.cmdExecutor = mockExecutor
+
+	result := runSedTool(t, sed, map[string]interface{}{
+		"expression": "s/a/b/p",
+		"files":      []string{"file.txt"},
+		"options":    []string{"-n"},
+	})
+
+	assert.False(t, result.IsError)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestSed_Content(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed binary not available")
+	}
+
+	sed := NewSed(newSedTestLogger())
+
+	result := runSedTool(t, sed, map[string]interface{}{
+		"expression": "s/world/sed/g",
+		"content":    "hello world\nbye world\n",
+	})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, "hello sed\nbye sed\n", result.Content[0].Text)
+}
+
+func TestFindUnsafeSedCommand(t *testing.T) {
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{"s/foo/bar/g", ""},
+		{"s/write/exec/", ""},
+		{"/error/d", ""},
+		{"1,$s|a|b|", ""},
+		{"y/abc/xyz/", ""},
+		{"a\\ write this", ""},
+		{`s/a\/w/b/`, ""},
+		{"w out.txt", "w"},
+		{"$W out.txt", "W"},
+		{"/x/,/y/ r /etc/passwd", "r"},
+		{"1!R other.txt", "R"},
+		{"e date", "e"},
+		{"s/a/b/w out.txt", "s///w"},
+		{"s/a/b/ge", "s///e"},
+		{"p; {e id}", "e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.script, func(t *testing.T) {
+			cmd, found := findUnsafeSedCommand(tt.script)
+			assert.Equal(t, tt.expected != "", found)
+			assert.Equal(t, tt.expected, cmd)
+		})
+	}
+}
+
+func TestSed_Tracing(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.Anything).Return([]byte("b"), nil)
+
+	sed := NewSed(newSedTestLogger())
+	sed.cmdExecutor = mockExecutor
+
+	ctx, recorder := newTracingTestContext(t)
+	inputJSON, err := json.Marshal(map[string]interface{}{"expression": "s/a/b/", "files": []string{"file.txt"}})
+	require.NoError(t, err)
+	_, err = sed.SedAllInOneTool().Handler(ctx, goai.CallToolParams{Name: SedToolName, Arguments: inputJSON})
+	require.NoError(t, err)
+	assert.False(t, spanRecordedError(requireHandlerSpan(t, recorder, SedToolName)))
+
+	ctx, recorder = newTracingTestContext(t)
+	inputJSON, err = json.Marshal(map[string]interface{}{"expression": "s/a/b/", "files": []string{"file.txt"}, "options": []string{"-i"}})
+	require.NoError(t, err)
+	_, err = sed.SedAllInOneTool().Handler(ctx, goai.CallToolParams{Name: SedToolName, Arguments: inputJSON})
+	require.NoError(t, err)
+	assert.True(t, spanRecordedError(requireHandlerSpan(t, recorder, SedToolName)))
+}
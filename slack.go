@@ -0,0 +1,440 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	SlackToolName = "slack"
+
+	// slackDefaultBaseURL is the Slack Web API endpoint used when no base URL is configured
+	slackDefaultBaseURL = "https://slack.com/api"
+
+	// slackDefaultLimit is the page size of the list_channels and read_history operations
+	slackDefaultLimit = 100
+)
+
+// Slack represents a client for the Slack Web API,
+// providing a programmatic interface for posting messages and reading channels.
+type Slack struct {
+	logger     goai.Logger
+	httpClient *http.Client
+	config     SlackConfig
+}
+
+// SlackConfig holds the configuration for the Slack tool
+type SlackConfig struct {
+	// Token is the bot or user token sent as a bearer token with every request
+	Token string
+	// BaseURL overrides the Slack Web API endpoint, e.g. for a proxy. Defaults to https://slack.com/api
+	BaseURL string
+	// Retry controls retries of read requests, e.g. when Slack rate limits the client
+	Retry RetryConfig
+}
+
+// SlackChannel is a conversation returned by the list_channels operation
+type SlackChannel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsPrivate  bool   `json:"is_private"`
+	IsArchived bool   `json:"is_archived"`
+	NumMembers int    `json:"num_members"`
+	Topic      string `json:"topic,omitempty"`
+}
+
+// SlackMessage is a channel message returned by the read_history operation
+type SlackMessage struct {
+	TS         string `json:"ts"`
+	User       string `json:"user,omitempty"`
+	Text       string `json:"text"`
+	ThreadTS   string `json:"thread_ts,omitempty"`
+	ReplyCount int    `json:"reply_count,omitempty"`
+}
+
+// slackResponse holds the fields shared by every Slack Web API response
+type slackResponse struct {
+	OK               bool   `json:"ok"`
+	Error            string `json:"error,omitempty"`
+	ResponseMetadata struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+// NewSlack creates and returns a new instance of the Slack client with the provided configuration.
+func NewSlack(logger goai.Logger, config SlackConfig) *Slack {
+	if config.BaseURL == "" {
+		config.BaseURL = slackDefaultBaseURL
+	}
+
+	return &Slack{
+		logger:     logger,
+		httpClient: http.DefaultClient,
+		config:     config,
+	}
+}
+
+// SlackAllInOneTool returns a goai.Tool that can perform various Slack operations
+func (s *Slack) SlackAllInOneTool() goai.Tool {
+	return withInputValidation(s.logger, goai.Tool{
+		Name:        SlackToolName,
+		Description: "Performs Slack operations such as posting messages, listing channels, reading channel history and uploading files",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"description": "Slack operation to execute",
+					"enum": ["post_message", "list_channels", "read_history", "upload_file"]
+				},
+				"channel": {
+					"type": "string",
+					"description": "Channel ID for post_message, read_history and upload_file operations"
+				},
+				"text": {
+					"type": "string",
+					"description": "Message text for post_message, or the comment posted with the file for upload_file"
+				},
+				"thread_ts": {
+					"type": "string",
+					"description": "Timestamp of the parent message to reply in a thread (for post_message and upload_file)"
+				},
+				"filename": {
+					"type": "string",
+					"description": "Name of the uploaded file (for upload_file operation)"
+				},
+				"content": {
+					"type": "string",
+					"description": "Text content of the uploaded file (for upload_file operation)"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of channels or messages to return (default: 100)"
+				},
+				"cursor": {
+					"type": "string",
+					"description": "Pagination cursor returned as next_cursor by a previous list_channels or read_history call"
+				},
+				"oldest": {
+					"type": "string",
+					"description": "Only return messages after this timestamp (for read_history operation)"
+				},
+				"latest": {
+					"type": "string",
+					"description": "Only return messages before this timestamp (for read_history operation)"
+				}
+			},
+			"required": ["operation"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			s.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Starting Slack operation execution")
+
+			var input struct {
+				Operation string `json:"operation"`
+				Channel   string `json:"channel,omitempty"`
+				Text      string `json:"text,omitempty"`
+				ThreadTS  string `json:"thread_ts,omitempty"`
+				Filename  string `json:"filename,omitempty"`
+				Content   string `json:"content,omitempty"`
+				Limit     int    `json:"limit,omitempty"`
+				Cursor    string `json:"cursor,omitempty"`
+				Oldest    string `json:"oldest,omitempty"`
+				Latest    string `json:"latest,omitempty"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"raw_input":        string(params.Arguments),
+				}).Error("Failed to unmarshal input parameters")
+
+				span.RecordError(err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+			}
+
+			var result interface{}
+			var err error
+
+			switch {
+			case s.config.Token == "":
+				err = newToolError(ErrorKindInternal, errors.New("slack token is not configured"))
+			case input.Limit < 0:
+				err = validationErrorf("limit must not be negative")
+			default:
+				switch input.Operation {
+				case "post_message":
+					result, err = s.postMessage(ctx, input.Channel, input.Text, input.ThreadTS)
+				case "list_channels":
+					result, err = s.listChannels(ctx, input.Limit, input.Cursor)
+				case "read_history":
+					result, err = s.readHistory(ctx, input.Channel, input.Limit, input.Cursor, input.Oldest, input.Latest)
+				case "upload_file":
+					result, err = s.uploadFile(ctx, input.Channel, input.Filename, input.Content, input.Text, input.ThreadTS)
+				default:
+					err = validationErrorf("unsupported operation: %s", input.Operation)
+				}
+			}
+
+			var output []byte
+			if err == nil {
+				if output, err = json.Marshal(result); err != nil {
+					err = newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal result: %w", err))
+				}
+			}
+
+			if err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"operation":        input.Operation,
+				}).Error("Slack operation failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			s.logger.WithFields(map[string]interface{}{
+				"tool":          SlackToolName,
+				"operation":     input.Operation,
+				"result_length": len(output),
+			}).Debug("Slack operation completed successfully")
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "text",
+					Text: string(output),
+				}},
+			}, nil
+		},
+	})
+}
+
+func (s *Slack) postMessage(ctx context.Context, channel, text, threadTS string) (interface{}, error) {
+	if channel == "" || text == "" {
+		return nil, validationErrorf("channel and text are required for post_message operation")
+	}
+
+	payload := map[string]string{"channel": channel, "text": text}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
+	}
+
+	var resp struct {
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}
+	if err := s.call(ctx, http.MethodPost, "chat.postMessage", nil, payload, &resp); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"channel": resp.Channel, "ts": resp.TS}, nil
+}
+
+func (s *Slack) listChannels(ctx context.Context, limit int, cursor string) (interface{}, error) {
+	query := url.Values{}
+	query.Set("types", "public_channel,private_channel")
+	query.Set("exclude_archived", "true")
+	query.Set("limit", strconv.Itoa(slackLimit(limit)))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var resp struct {
+		slackResponse
+		Channels []struct {
+			SlackChannel
+			Topic struct {
+				Value string `json:"value"`
+			} `json:"topic"`
+		} `json:"channels"`
+	}
+	if err := s.call(ctx, http.MethodGet, "conversations.list", query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	channels := make([]SlackChannel, 0, len(resp.Channels))
+	for _, c := range resp.Channels {
+		channel := c.SlackChannel
+		channel.Topic = c.Topic.Value
+		channels = append(channels, channel)
+	}
+
+	return struct {
+		Channels   []SlackChannel `json:"channels"`
+		NextCursor string         `json:"next_cursor,omitempty"`
+	}{channels, resp.ResponseMetadata.NextCursor}, nil
+}
+
+func (s *Slack) readHistory(ctx context.Context, channel string, limit int, cursor, oldest, latest string) (interface{}, error) {
+	if channel == "" {
+		return nil, validationErrorf("channel is required for read_history operation")
+	}
+
+	query := url.Values{}
+	query.Set("channel", channel)
+	query.Set("limit", strconv.Itoa(slackLimit(limit)))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if oldest != "" {
+		query.Set("oldest", oldest)
+	}
+	if latest != "" {
+		query.Set("latest", latest)
+	}
+
+	var resp struct {
+		slackResponse
+		Messages []SlackMessage `json:"messages"`
+	}
+	if err := s.call(ctx, http.MethodGet, "conversations.history", query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	messages := resp.Messages
+	if messages == nil {
+		messages = []SlackMessage{}
+	}
+
+	return struct {
+		Messages   []SlackMessage `json:"messages"`
+		NextCursor string         `json:"next_cursor,omitempty"`
+	}{messages, resp.ResponseMetadata.NextCursor}, nil
+}
+
+// uploadFile shares a text file in a channel using Slack's external upload flow:
+// reserve an upload URL, send the content to it, then complete the upload
+func (s *Slack) uploadFile(ctx context.Context, channel, filename, content, comment, threadTS string) (interface{}, error) {
+	if channel == "" || filename == "" || content == "" {
+		return nil, validationErrorf("channel, filename and content are required for upload_file operation")
+	}
+
+	query := url.Values{}
+	query.Set("filename", filename)
+	query.Set("length", strconv.Itoa(len(content)))
+
+	var upload struct {
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	if err := s.call(ctx, http.MethodGet, "files.getUploadURLExternal", query, nil, &upload); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, upload.UploadURL, bytes.NewReader([]byte(content)))
+	if err != nil {
+		return nil, newToolError(ErrorKindInternal, fmt.Errorf("failed to create upload request: %w", err))
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, upstreamErrorf("failed to upload file: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, upstreamErrorf("failed to upload file: unexpected status %d", resp.StatusCode)
+	}
+
+	payload := map[string]interface{}{
+		"files":      []map[string]string{{"id": upload.FileID, "title": filename}},
+		"channel_id": channel,
+	}
+	if comment != "" {
+		payload["initial_comment"] = comment
+	}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
+	}
+
+	if err := s.call(ctx, http.MethodPost, "files.completeUploadExternal", nil, payload, nil); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"file_id": upload.FileID, "channel": channel}, nil
+}
+
+// call sends a request to a Slack Web API method and decodes the response into
+// out. Slack reports failures with "ok": false and an error code in a 200
+// response, so both the status code and the ok field are checked.
+func (s *Slack) call(ctx context.Context, method, apiMethod string, query url.Values, payload interface{}, out interface{}) error {
+	endpoint := s.config.BaseURL + "/" + apiMethod
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return newToolError(ErrorKindInternal, fmt.Errorf("failed to marshal %s request: %w", apiMethod, err))
+		}
+	}
+
+	resp, err := retryDo(ctx, s.httpClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+s.config.Token)
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		}
+		return req, nil
+	}, s.config.Retry)
+	if err != nil {
+		return upstreamErrorf("slack %s request failed: %w", apiMethod, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return upstreamErrorf("failed to read slack %s response: %w", apiMethod, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return upstreamErrorf("slack %s returned status %d", apiMethod, resp.StatusCode)
+	}
+
+	var status slackResponse
+	if err := json.Unmarshal(data, &status); err != nil {
+		return upstreamErrorf("failed to decode slack %s response: %w", apiMethod, err)
+	}
+	if !status.OK {
+		return upstreamErrorf("slack %s failed: %s", apiMethod, status.Error)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return upstreamErrorf("failed to decode slack %s response: %w", apiMethod, err)
+	}
+	return nil
+}
+
+// slackLimit returns the page size to request, falling back to the default
+func slackLimit(limit int) int {
+	if limit <= 0 {
+		return slackDefaultLimit
+	}
+	return limit
+}
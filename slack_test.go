@@ -0,0 +1,219 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// slackRoundTripFunc serves Slack API requests from a function instead of the network
+type slackRoundTripFunc func(req *http.Request) *http.Response
+
+func (f slackRoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func slackJSONResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func setupSlackTest(t *testing.T, config SlackConfig, handler slackRoundTripFunc) *Slack {
+	mockLogger := &MockLogger{}
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger)
+	mockLogger.On("Info", mock.Anything).Return()
+	mockLogger.On("Debug", mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything).Return()
+
+	s := NewSlack(mockLogger, config)
+	s.httpClient = &http.Client{Transport: handler}
+	return s
+}
+
+func callSlackTool(t *testing.T, s *Slack, input map[string]interface{}) goai.CallToolResult {
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := s.SlackAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      SlackToolName,
+		Arguments: inputBytes,
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestSlack_PostMessage(t *testing.T) {
+	var called bool
+	s := setupSlackTest(t, SlackConfig{Token: "xoxb-test"}, func(req *http.Request) *http.Response {
+		called = true
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://slack.com/api/chat.postMessage", req.URL.String())
+		assert.Equal(t, "Bearer xoxb-test", req.Header.Get("Authorization"))
+
+		var payload map[string]string
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		assert.Equal(t, map[string]string{"channel": "C123", "text": "Deploy finished", "thread_ts": "1700000000.000100"}, payload)
+
+		return slackJSONResponse(`{"ok":true,"channel":"C123","ts":"1700000001.000200"}`)
+	})
+
+	result := callSlackTool(t, s, map[string]interface{}{
+		"operation": "post_message",
+		"channel":   "C123",
+		"text":      "Deploy finished",
+		"thread_ts": "1700000000.000100",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.True(t, called)
+	assert.JSONEq(t, `{"channel":"C123","ts":"1700000001.000200"}`, result.Content[0].Text)
+}
+
+func TestSlack_ListChannels(t *testing.T) {
+	s := setupSlackTest(t, SlackConfig{Token: "xoxb-test"}, func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/api/conversations.list", req.URL.Path)
+		assert.Equal(t, "10", req.URL.Query().Get("limit"))
+		assert.Equal(t, "page-2", req.URL.Query().Get("cursor"))
+
+		return slackJSONResponse(`{"ok":true,"channels":[{"id":"C1","name":"general","is_private":false,"num_members":12,"topic":{"value":"Company news"}}],"response_metadata":{"next_cursor":"page-3"}}`)
+	})
+
+	result := callSlackTool(t, s, map[string]interface{}{
+		"operation": "list_channels",
+		"limit":     10,
+		"cursor":    "page-2",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.JSONEq(t, `{"channels":[{"id":"C1","name":"general","is_private":false,"is_archived":false,"num_members":12,"topic":"Company news"}],"next_cursor":"page-3"}`, result.Content[0].Text)
+}
+
+func TestSlack_ReadHistory(t *testing.T) {
+	s := setupSlackTest(t, SlackConfig{Token: "xoxb-test"}, func(req *http.Request) *http.Response {
+		assert.Equal(t, "/api/conversations.history", req.URL.Path)
+		assert.Equal(t, "C123", req.URL.Query().Get("channel"))
+		assert.Equal(t, "100", req.URL.Query().Get("limit"))
+
+		return slackJSONResponse(`{"ok":true,"messages":[{"ts":"1.2","user":"U1","text":"hello"}]}`)
+	})
+
+	result := callSlackTool(t, s, map[string]interface{}{
+		"operation": "read_history",
+		"channel":   "C123",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.JSONEq(t, `{"messages":[{"ts":"1.2","user":"U1","text":"hello"}]}`, result.Content[0].Text)
+}
+
+func TestSlack_UploadFile(t *testing.T) {
+	var uploaded string
+	s := setupSlackTest(t, SlackConfig{Token: "xoxb-test"}, func(req *http.Request) *http.Response {
+		switch req.URL.Path {
+		case "/api/files.getUploadURLExternal":
+			assert.Equal(t, "report.csv", req.URL.Query().Get("filename"))
+			assert.Equal(t, "7", req.URL.Query().Get("length"))
+			return slackJSONResponse(`{"ok":true,"upload_url":"https://files.slack.com/upload/v1/abc","file_id":"F1"}`)
+		case "/upload/v1/abc":
+			body, _ := io.ReadAll(req.Body)
+			uploaded = string(body)
+			return slackJSONResponse(`OK`)
+		case "/api/files.completeUploadExternal":
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+			assert.Equal(t, "C123", payload["channel_id"])
+			assert.Equal(t, "Weekly numbers", payload["initial_comment"])
+			return slackJSONResponse(`{"ok":true,"files":[{"id":"F1"}]}`)
+		}
+		t.Errorf("unexpected request to %s", req.URL)
+		return slackJSONResponse(`{"ok":false,"error":"unknown_method"}`)
+	})
+
+	result := callSlackTool(t, s, map[string]interface{}{
+		"operation": "upload_file",
+		"channel":   "C123",
+		"filename":  "report.csv",
+		"content":   "a,b\n1,2",
+		"text":      "Weekly numbers",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "a,b\n1,2", uploaded)
+	assert.JSONEq(t, `{"file_id":"F1","channel":"C123"}`, result.Content[0].Text)
+}
+
+func TestSlack_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        SlackConfig
+		input         map[string]interface{}
+		expectedError string
+		expectedKind  ErrorKind
+	}{
+		{
+			name:          "missing token",
+			config:        SlackConfig{},
+			input:         map[string]interface{}{"operation": "list_channels"},
+			expectedError: "slack token is not configured",
+			expectedKind:  ErrorKindInternal,
+		},
+		{
+			name:          "missing channel",
+			config:        SlackConfig{Token: "xoxb-test"},
+			input:         map[string]interface{}{"operation": "post_message", "text": "hi"},
+			expectedError: "channel and text are required",
+			expectedKind:  ErrorKindValidation,
+		},
+		{
+			name:          "negative limit",
+			config:        SlackConfig{Token: "xoxb-test"},
+			input:         map[string]interface{}{"operation": "list_channels", "limit": -1},
+			expectedError: "limit must not be negative",
+			expectedKind:  ErrorKindValidation,
+		},
+		{
+			name:          "slack error response",
+			config:        SlackConfig{Token: "xoxb-test"},
+			input:         map[string]interface{}{"operation": "post_message", "channel": "C404", "text": "hi"},
+			expectedError: "slack chat.postMessage failed: channel_not_found",
+			expectedKind:  ErrorKindUpstream,
+		},
+		{
+			name:          "unsupported operation",
+			config:        SlackConfig{Token: "xoxb-test"},
+			input:         map[string]interface{}{"operation": "archive"},
+			expectedError: "operation: operation must be one of the following",
+			expectedKind:  ErrorKindValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupSlackTest(t, tt.config, func(req *http.Request) *http.Response {
+				return slackJSONResponse(`{"ok":false,"error":"channel_not_found"}`)
+			})
+
+			result := callSlackTool(t, s, tt.input)
+
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+
+			kind, ok := ResultErrorKind(result)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedKind, kind)
+		})
+	}
+}
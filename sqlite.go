@@ -0,0 +1,342 @@
+package mcptools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+	_ "modernc.org/sqlite" // registers the pure-Go "sqlite" database/sql driver
+)
+
+// SQLiteToolName is the name of the SQLite tool
+const SQLiteToolName = "sqlite"
+
+// attachPattern matches the ATTACH statement, which could open or create database files outside the allowed directory
+var attachPattern = regexp.MustCompile(`(?i)\battach\b`)
+
+// vacuumIntoPattern matches VACUUM INTO, which writes a copy of the database to any file
+var vacuumIntoPattern = regexp.MustCompile(`(?i)\bvacuum\b[^;]*\binto\b`)
+
+// SQLite represents a tool for querying local SQLite database files
+type SQLite struct {
+	logger goai.Logger
+	config SQLiteConfig
+}
+
+// SQLiteConfig represents the configuration for the SQLite tool
+type SQLiteConfig struct {
+	AllowedDirectory string // Base directory database files must reside in; empty means unrestricted
+	AllowWrites      bool   // Opens databases read-write instead of read-only
+}
+
+// SQLiteColumn describes a column in the schema of a SQLite table
+type SQLiteColumn struct {
+	Name       string  `json:"name"`
+	Type       string  `json:"type"`
+	NotNull    bool    `json:"not_null"`
+	Default    *string `json:"default"`
+	PrimaryKey bool    `json:"primary_key"`
+}
+
+// NewSQLite creates a new SQLite tool with the given logger and configuration
+func NewSQLite(logger goai.Logger, config SQLiteConfig) *SQLite {
+	return &SQLite{
+		logger: logger,
+		config: config,
+	}
+}
+
+// SQLiteAllInOneTool returns a goai.Tool that queries and inspects SQLite database files
+func (s *SQLite) SQLiteAllInOneTool() goai.Tool {
+	return withInputValidation(s.logger, goai.Tool{
+		Name:        SQLiteToolName,
+		Description: "Performs SQLite operations on local database files including querying, listing tables and retrieving schema information",
+		InputSchema: json.RawMessage(`{
+            "type": "object",
+            "properties": {
+                "operation": {
+                    "type": "string",
+                    "description": "Operation to perform (query, schema, list_tables)",
+                    "enum": ["query", "schema", "list_tables"]
+                },
+                "database": {
+                    "type": "string",
+                    "description": "Path to an existing SQLite database file"
+                },
+                "query": {
+                    "type": "string",
+                    "description": "SQL query to execute (for query operation)"
+                },
+                "table": {
+                    "type": "string",
+                    "description": "Table name (for schema operation)"
+                }
+            },
+            "required": ["operation", "database"]
+        }`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			s.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Starting SQLite operation")
+
+			var input struct {
+				Operation string `json:"operation"`
+				Database  string `json:"database"`
+				Query     string `json:"query"`
+				Table     string `json:"table"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"raw_input":        string(params.Arguments),
+				}).Error("Failed to unmarshal input parameters")
+				span.RecordError(err)
+				return goai.CallToolResult{}, newToolError(ErrorKindValidation, fmt.Errorf("failed to unmarshal input: %w", err))
+			}
+
+			db, err := s.openDatabase(input.Database)
+			if err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField:  err,
+					"database":          input.Database,
+					"allowed_directory": s.config.AllowedDirectory,
+				}).Error("Failed to open database")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+			defer db.Close()
+
+			var result interface{}
+			switch input.Operation {
+			case "query":
+				if input.Query == "" {
+					return returnErrorOutput(validationErrorf("query is required for operation 'query'")), nil
+				}
+				if attachPattern.MatchString(input.Query) {
+					return returnErrorOutput(permissionErrorf("ATTACH is not allowed")), nil
+				}
+				if vacuumIntoPattern.MatchString(input.Query) {
+					return returnErrorOutput(permissionErrorf("VACUUM INTO is not allowed")), nil
+				}
+				result, err = s.executeQuery(ctx, db, input.Query)
+			case "schema":
+				if input.Table == "" {
+					return returnErrorOutput(validationErrorf("table is required for operation 'schema'")), nil
+				}
+				result, err = s.getTableSchema(ctx, db, input.Table)
+			case "list_tables":
+				result, err = s.listTables(ctx, db)
+			default:
+				return returnErrorOutput(validationErrorf("unknown operation: %s", input.Operation)), nil
+			}
+
+			if err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"operation":        input.Operation,
+					"database":         input.Database,
+				}).Error("SQLite operation failed")
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			m, ok := result.(string)
+			if !ok {
+				m = mustMarshal(result)
+			}
+
+			s.logger.WithFields(map[string]interface{}{
+				"tool":          SQLiteToolName,
+				"operation":     input.Operation,
+				"result_length": len(m),
+			}).Info("SQLite operation completed successfully")
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{Type: "text", Text: m}},
+			}, nil
+		},
+	})
+}
+
+// openDatabase validates the database path against the allowed directory and
+// opens the existing file without creating it, read-only unless AllowWrites is set
+func (s *SQLite) openDatabase(path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, validationErrorf("database is required")
+	}
+
+	allowed, err := isPathWithinDirectory(path, s.config.AllowedDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database path %s: %w", path, err)
+	}
+	if !allowed {
+		return nil, permissionErrorf("path outside allowed directory: %s", path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access database file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("database path is a directory: %s", path)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database path %s: %w", path, err)
+	}
+
+	// Neither mode creates a new database. Building the URI escapes characters
+	// such as ? and # in the path, which would otherwise start URI parameters.
+	mode := "ro"
+	if s.config.AllowWrites {
+		mode = "rw"
+	}
+	dsn := url.URL{Scheme: "file", Path: filepath.ToSlash(absPath), RawQuery: "mode=" + mode}
+	return sql.Open("sqlite", dsn.String())
+}
+
+// executeQuery runs the query and returns the rows as JSON objects, or the
+// number of affected rows for INSERT, UPDATE, DELETE and MERGE statements
+func (s *SQLite) executeQuery(ctx context.Context, db *sql.DB, query string) (interface{}, error) {
+	s.logger.WithFields(map[string]interface{}{
+		"tool":      SQLiteToolName,
+		"operation": "executeQuery",
+		"query":     query,
+	}).Info("Executing query")
+
+	if !returnsRows(query) {
+		res, err := db.ExecContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("%d rows affected", affected), nil
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		// A statement without a result set, e.g. CREATE TABLE
+		for rows.Next() {
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return noRowsReturnedMessage, nil
+	}
+
+	return scanRowsAsMaps(rows)
+}
+
+// getTableSchema returns the columns of the given table
+func (s *SQLite) getTableSchema(ctx context.Context, db *sql.DB, table string) ([]SQLiteColumn, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []SQLiteColumn{}
+	for rows.Next() {
+		var (
+			column       SQLiteColumn
+			defaultValue sql.NullString
+			primaryKey   int
+		)
+		if err := rows.Scan(&column.Name, &column.Type, &column.NotNull, &defaultValue, &primaryKey); err != nil {
+			return nil, err
+		}
+		if defaultValue.Valid {
+			column.Default = &defaultValue.String
+		}
+		column.PrimaryKey = primaryKey > 0
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, newToolError(ErrorKindNotFound, fmt.Errorf("table not found: %s", table))
+	}
+	return columns, nil
+}
+
+// listTables returns the names of all user tables in the database
+func (s *SQLite) listTables(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// scanRowsAsMaps reads all rows into maps keyed by column name
+func scanRowsAsMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	valuePtrs := make([]interface{}, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	result := []map[string]interface{}{}
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,260 @@
+package mcptools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSQLiteTest(t *testing.T) (*SQLite, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			role TEXT DEFAULT 'member'
+		);
+		CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT);
+		INSERT INTO users (id, name, role) VALUES (1, 'alice', 'admin'), (2, 'bob', 'member');
+	`)
+	require.NoError(t, err)
+
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Info", mock.Anything).Return()
+	logger.On("Error", mock.Anything).Return()
+
+	return NewSQLite(logger, SQLiteConfig{AllowedDirectory: dir}), dbPath
+}
+
+func callSQLiteTool(t *testing.T, s *SQLite, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+
+	inputJSON, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := s.SQLiteAllInOneTool().Handler(context.Background(), goai.CallToolParams{
+		Name:      SQLiteToolName,
+		Arguments: inputJSON,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestSQLite_Query(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "SELECT id, name, role FROM users ORDER BY id",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var rows []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &rows))
+	assert.Equal(t, []map[string]interface{}{
+		{"id": float64(1), "name": "alice", "role": "admin"},
+		{"id": float64(2), "name": "bob", "role": "member"},
+	}, rows)
+}
+
+func TestSQLite_QueryUpdateReportsAffectedRows(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+	s.config.AllowWrites = true
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "UPDATE users SET role = 'guest'",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "2 rows affected", result.Content[0].Text)
+}
+
+func TestSQLite_QueryPragmaReturnsRows(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "PRAGMA table_info(posts)",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var rows []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &rows))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "user_id", rows[1]["name"])
+}
+
+func TestSQLite_VacuumIntoDoesNotWriteFile(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+	target := filepath.Join(t.TempDir(), "copy.db")
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "vacuum into '" + target + "'",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "VACUUM INTO is not allowed")
+	assert.NoFileExists(t, target)
+}
+
+func TestSQLite_QueryWithoutResultSet(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+	s.config.AllowWrites = true
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "/* audit */ CREATE TABLE audit (id INTEGER)",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, noRowsReturnedMessage, result.Content[0].Text)
+
+	result = callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "list_tables",
+		"database":  dbPath,
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.JSONEq(t, `["audit", "posts", "users"]`, result.Content[0].Text)
+}
+
+func TestSQLite_ReadOnlyByDefault(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "UPDATE users SET role = 'guest'",
+	})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "readonly database")
+
+	result = callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "query",
+		"database":  dbPath,
+		"query":     "SELECT role FROM users WHERE id = 2",
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.JSONEq(t, `[{"role": "member"}]`, result.Content[0].Text)
+}
+
+func TestSQLite_PathWithURICharacters(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+	oddPath := filepath.Join(filepath.Dir(dbPath), "odd?mode=rwc#name%20.db")
+	require.NoError(t, os.Rename(dbPath, oddPath))
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "list_tables",
+		"database":  oddPath,
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.JSONEq(t, `["posts", "users"]`, result.Content[0].Text)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dbPath), "odd"))
+}
+
+func TestSQLite_ListTables(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "list_tables",
+		"database":  dbPath,
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.JSONEq(t, `["posts", "users"]`, result.Content[0].Text)
+}
+
+func TestSQLite_Schema(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+
+	result := callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "schema",
+		"database":  dbPath,
+		"table":     "users",
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	var columns []SQLiteColumn
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &columns))
+	require.Len(t, columns, 3)
+	assert.Equal(t, SQLiteColumn{Name: "id", Type: "INTEGER", PrimaryKey: true}, columns[0])
+	assert.Equal(t, SQLiteColumn{Name: "name", Type: "TEXT", NotNull: true}, columns[1])
+	require.NotNil(t, columns[2].Default)
+	assert.Equal(t, "'member'", *columns[2].Default)
+
+	result = callSQLiteTool(t, s, map[string]interface{}{
+		"operation": "schema",
+		"database":  dbPath,
+		"table":     "missing",
+	})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "table not found: missing")
+}
+
+func TestSQLite_Validation(t *testing.T) {
+	s, dbPath := setupSQLiteTest(t)
+
+	tests := []struct {
+		name          string
+		input         map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "database outside allowed directory",
+			input:         map[string]interface{}{"operation": "list_tables", "database": filepath.Join(t.TempDir(), "other.db")},
+			expectedError: "path outside allowed directory",
+		},
+		{
+			name:          "missing database file is not created",
+			input:         map[string]interface{}{"operation": "list_tables", "database": filepath.Join(filepath.Dir(dbPath), "missing.db")},
+			expectedError: "failed to access database file",
+		},
+		{
+			name:          "attach is rejected",
+			input:         map[string]interface{}{"operation": "query", "database": dbPath, "query": "ATTACH DATABASE '/tmp/x.db' AS x"},
+			expectedError: "ATTACH is not allowed",
+		},
+		{
+			name:          "vacuum into is rejected",
+			input:         map[string]interface{}{"operation": "query", "database": dbPath, "query": "VACUUM main INTO '/tmp/x.db'"},
+			expectedError: "VACUUM INTO is not allowed",
+		},
+		{
+			name:          "unknown operation",
+			input:         map[string]interface{}{"operation": "drop", "database": dbPath},
+			expectedError: "operation: operation must be one of the following",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callSQLiteTool(t, s, tt.input)
+
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expectedError)
+		})
+	}
+}